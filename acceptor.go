@@ -4,11 +4,13 @@ import (
 	"bufio"
 	"bytes"
 	"crypto/tls"
+	"errors"
 	"io"
 	"net"
 	"runtime/debug"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/quickfixgo/quickfix/config"
 )
@@ -25,8 +27,14 @@ type Acceptor struct {
 	listener           net.Listener
 	listenerShutdown   sync.WaitGroup
 	dynamicSessions    bool
-	dynamicSessionChan chan *session
+	dynamicSessionChan chan *dynamicSession
+	connections        *connectionTracker
+	firstMsgTimeout    time.Duration
 	sessionFactory
+
+	dynamicSessionPolicy  DynamicSessionPolicy
+	dynamicSessionsLock   sync.Mutex
+	activeDynamicSessions map[SessionID]*dynamicSession
 }
 
 //Start accepting connections.
@@ -68,8 +76,8 @@ func (a *Acceptor) Start() error {
 			a.sessionGroup.Done()
 		}()
 	}
-	if a.dynamicSessions {
-		a.dynamicSessionChan = make(chan *session)
+	if a.createsDynamicSessions() {
+		a.dynamicSessionChan = make(chan *dynamicSession)
 		a.sessionGroup.Add(1)
 		go func() {
 			a.dynamicSessionsLoop()
@@ -89,7 +97,7 @@ func (a *Acceptor) Stop() {
 
 	a.listener.Close()
 	a.listenerShutdown.Wait()
-	if a.dynamicSessions {
+	if a.createsDynamicSessions() {
 		close(a.dynamicSessionChan)
 	}
 	for _, session := range a.sessions {
@@ -106,6 +114,8 @@ func NewAcceptor(app Application, storeFactory MessageStoreFactory, settings *Se
 		settings:     settings,
 		logFactory:   logFactory,
 		sessions:     make(map[SessionID]*session),
+
+		activeDynamicSessions: make(map[SessionID]*dynamicSession),
 	}
 	if a.settings.GlobalSettings().HasSetting(config.DynamicSessions) {
 		if a.dynamicSessions, err = settings.globalSettings.BoolSetting(config.DynamicSessions); err != nil {
@@ -113,6 +123,29 @@ func NewAcceptor(app Application, storeFactory MessageStoreFactory, settings *Se
 		}
 	}
 
+	if a.dynamicSessionPolicy, err = parseDynamicSessionPolicy(settings.globalSettings); err != nil {
+		return
+	}
+
+	var maxConnections int
+	if a.settings.GlobalSettings().HasSetting(config.AcceptorMaxConnections) {
+		if maxConnections, err = settings.globalSettings.IntSetting(config.AcceptorMaxConnections); err != nil {
+			return
+		}
+
+		if maxConnections <= 0 {
+			err = errors.New("AcceptorMaxConnections must be greater than zero")
+			return
+		}
+	}
+	a.connections = newConnectionTracker(maxConnections)
+
+	if a.settings.GlobalSettings().HasSetting(config.AcceptorFirstMessageTimeout) {
+		if a.firstMsgTimeout, err = settings.globalSettings.DurationSetting(config.AcceptorFirstMessageTimeout); err != nil {
+			return
+		}
+	}
+
 	if a.globalLog, err = logFactory.Create(); err != nil {
 		return
 	}
@@ -142,8 +175,18 @@ func (a *Acceptor) listenForConnections() {
 			return
 		}
 
+		remote := remoteHost(netConn.RemoteAddr())
+		if !a.connections.add(remote) {
+			a.globalLog.OnEventf("Connection from %v refused, maximum connections reached", netConn.RemoteAddr())
+			if err := netConn.Close(); err != nil {
+				a.globalLog.OnEvent(err.Error())
+			}
+			continue
+		}
+
 		go func() {
 			a.handleConnection(netConn)
+			a.connections.remove(remote)
 		}()
 	}
 }
@@ -166,6 +209,13 @@ func (a *Acceptor) handleConnection(netConn net.Conn) {
 	reader := bufio.NewReader(netConn)
 	parser := newParser(reader)
 
+	if a.firstMsgTimeout > 0 {
+		if err := netConn.SetReadDeadline(time.Now().Add(a.firstMsgTimeout)); err != nil {
+			a.globalLog.OnEvent(err.Error())
+			return
+		}
+	}
+
 	msgBytes, err := parser.ReadMessage()
 	if err != nil {
 		if err == io.EOF {
@@ -176,6 +226,13 @@ func (a *Acceptor) handleConnection(netConn net.Conn) {
 		return
 	}
 
+	if a.firstMsgTimeout > 0 {
+		if err := netConn.SetReadDeadline(time.Time{}); err != nil {
+			a.globalLog.OnEvent(err.Error())
+			return
+		}
+	}
+
 	msg := NewMessage()
 	err = ParseMessage(msg, msgBytes)
 	if err != nil {
@@ -238,25 +295,46 @@ func (a *Acceptor) handleConnection(netConn net.Conn) {
 		TargetCompID: string(senderCompID), TargetSubID: string(senderSubID), TargetLocationID: string(senderLocationID),
 	}
 	session, ok := a.sessions[sessID]
-	if !ok {
-		if !a.dynamicSessions {
+	if ok {
+		if a.refusesRemote(sessID, netConn.RemoteAddr(), session.remoteLimits()) {
+			return
+		}
+	} else {
+		if !a.createsDynamicSessions() {
 			a.globalLog.OnEventf("Session %v not found for incoming message: %s", sessID, msgBytes)
 			return
 		}
-		dynamicSession, err := a.sessionFactory.createSession(sessID, a.storeFactory, a.settings.globalSettings.clone(), a.logFactory, a.app)
+
+		//remote limits are applied before a dynamic session is created or resumed
+		settings, ok := a.dynamicSessionSettings(sessID)
+		if !ok {
+			a.globalLog.OnEventf("Connection from %v refused: session %v not found, incoming message: %s", netConn.RemoteAddr(), sessID, msgBytes)
+			return
+		}
+
+		limits, err := parseRemoteLimits(settings)
 		if err != nil {
-			a.globalLog.OnEventf("Dynamic session %v failed to create: %v", sessID, err)
+			a.globalLog.OnEventf("Connection from %v refused: %v, incoming message: %s", netConn.RemoteAddr(), err, msgBytes)
 			return
 		}
-		a.dynamicSessionChan <- dynamicSession
-		session = dynamicSession
-		defer session.stop()
+
+		if a.refusesRemote(sessID, netConn.RemoteAddr(), limits) {
+			return
+		}
+
+		dynamicSession, err := a.acquireDynamicSession(sessID, settings, netConn.RemoteAddr())
+		if err != nil {
+			a.globalLog.OnEventf("Connection from %v refused: %v, incoming message: %s", netConn.RemoteAddr(), err, msgBytes)
+			return
+		}
+		session = dynamicSession.session
+		defer a.releaseDynamicSession(dynamicSession)
 	}
 
 	msgIn := make(chan fixIn)
 	msgOut := make(chan []byte)
 
-	if err := session.connect(msgIn, msgOut); err != nil {
+	if err := session.connect(msgIn, msgOut, netConn); err != nil {
 		a.globalLog.OnEventf("Unable to accept %v", err.Error())
 		return
 	}
@@ -269,9 +347,49 @@ func (a *Acceptor) handleConnection(netConn net.Conn) {
 	writeLoop(netConn, msgOut, a.globalLog)
 }
 
+//refusesRemote returns true, logging the reason, if limits do not allow the connection from addr to sessionID.
+func (a *Acceptor) refusesRemote(sessionID SessionID, addr net.Addr, limits remoteLimits) bool {
+	if !limits.isAllowed(addr) {
+		a.globalLog.OnEventf("Connection from %v not allowed for session %v", addr, sessionID)
+		return true
+	}
+
+	if limits.maxConnectionsPerRemote > 0 && a.connections.remoteCount(remoteHost(addr)) > limits.maxConnectionsPerRemote {
+		a.globalLog.OnEventf("Connection from %v refused for session %v, maximum connections per remote reached", addr, sessionID)
+		return true
+	}
+
+	return false
+}
+
+//createsDynamicSessions returns true if the acceptor creates sessions on the fly, either from acceptor templates or
+//for any incoming session when DynamicSessions is enabled.
+func (a *Acceptor) createsDynamicSessions() bool {
+	return a.dynamicSessions || len(a.settings.templates) > 0
+}
+
+//dynamicSessionSettings returns the settings for a session that is not statically configured. Sessions matching an
+//acceptor template use the template settings, otherwise the global settings are used if DynamicSessions is enabled.
+func (a *Acceptor) dynamicSessionSettings(sessionID SessionID) (*SessionSettings, bool) {
+	if template, ok := a.settings.acceptorTemplateFor(sessionID); ok {
+		if matcher, ok := a.app.(AcceptorTemplateMatcher); ok && !matcher.MatchAcceptorTemplate(sessionID, template.templateID) {
+			a.globalLog.OnEventf("Session %v refused by acceptor template %v", sessionID, template.templateID)
+			return nil, false
+		}
+
+		return a.settings.sessionSettingsFor(sessionID)
+	}
+
+	if !a.dynamicSessions {
+		return nil, false
+	}
+
+	return a.settings.globalSettings.clone(), true
+}
+
 func (a *Acceptor) dynamicSessionsLoop() {
 	var id int
-	var sessions = map[int]*session{}
+	var sessions = map[int]*dynamicSession{}
 	var complete = make(chan int)
 	defer close(complete)
 LOOP:
@@ -280,7 +398,7 @@ LOOP:
 		case session, ok := <-a.dynamicSessionChan:
 			if !ok {
 				for _, oldSession := range sessions {
-					oldSession.stop()
+					a.stopDynamicSession(oldSession)
 				}
 				break LOOP
 			}
@@ -290,6 +408,7 @@ LOOP:
 			go func() {
 				session.run()
 				err := UnregisterSession(session.sessionID)
+				a.removeDynamicSession(session)
 				if err != nil {
 					a.globalLog.OnEventf("Unregister dynamic session %v failed: %v", session.sessionID, err)
 					return
@@ -0,0 +1,89 @@
+package quickfix
+
+import (
+	"regexp"
+
+	"github.com/quickfixgo/quickfix/config"
+)
+
+//AcceptorTemplateMatcher may optionally be implemented by an Application to approve sessions created from acceptor
+//templates, e.g. by looking up the counterparty in a client database. MatchAcceptorTemplate is called after the
+//template's SessionID patterns have matched the incoming logon. Returning false refuses the connection.
+type AcceptorTemplateMatcher interface {
+	MatchAcceptorTemplate(sessionID SessionID, templateID SessionID) bool
+}
+
+//acceptorTemplate is a session definition matching any number of incoming session IDs. The CompID, SubID and LocationID
+//settings of a template are either * (matching any value) or regular expressions matching the whole value.
+type acceptorTemplate struct {
+	templateID SessionID
+	settings   *SessionSettings
+
+	senderCompID, senderSubID, senderLocationID *regexp.Regexp
+	targetCompID, targetSubID, targetLocationID *regexp.Regexp
+}
+
+func compileSessionIDPattern(setting, pattern string) (*regexp.Regexp, error) {
+	var expr string
+	switch pattern {
+	case "*":
+		expr = ".*"
+	case "":
+		//unset fields only match sessions without the field
+	default:
+		expr = "(?:" + pattern + ")"
+	}
+
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return nil, IncorrectFormatForSetting{Setting: setting, Value: pattern, Err: err}
+	}
+	return re, nil
+}
+
+func newAcceptorTemplate(templateID SessionID, settings *SessionSettings) (t *acceptorTemplate, err error) {
+	t = &acceptorTemplate{templateID: templateID, settings: settings}
+
+	if t.senderCompID, err = compileSessionIDPattern(config.SenderCompID, templateID.SenderCompID); err != nil {
+		return
+	}
+
+	if t.senderSubID, err = compileSessionIDPattern(config.SenderSubID, templateID.SenderSubID); err != nil {
+		return
+	}
+
+	if t.senderLocationID, err = compileSessionIDPattern(config.SenderLocationID, templateID.SenderLocationID); err != nil {
+		return
+	}
+
+	if t.targetCompID, err = compileSessionIDPattern(config.TargetCompID, templateID.TargetCompID); err != nil {
+		return
+	}
+
+	if t.targetSubID, err = compileSessionIDPattern(config.TargetSubID, templateID.TargetSubID); err != nil {
+		return
+	}
+
+	t.targetLocationID, err = compileSessionIDPattern(config.TargetLocationID, templateID.TargetLocationID)
+	return
+}
+
+//matches returns true if the template applies to sessionID.
+func (t *acceptorTemplate) matches(sessionID SessionID) bool {
+	return t.templateID.BeginString == sessionID.BeginString &&
+		t.senderCompID.MatchString(sessionID.SenderCompID) &&
+		t.senderSubID.MatchString(sessionID.SenderSubID) &&
+		t.senderLocationID.MatchString(sessionID.SenderLocationID) &&
+		t.targetCompID.MatchString(sessionID.TargetCompID) &&
+		t.targetSubID.MatchString(sessionID.TargetSubID) &&
+		t.targetLocationID.MatchString(sessionID.TargetLocationID)
+}
+
+//isAcceptorTemplate returns true if the session settings define an acceptor template.
+func isAcceptorTemplate(settings *SessionSettings) (bool, error) {
+	if !settings.HasSetting(config.AcceptorTemplate) {
+		return false, nil
+	}
+
+	return settings.BoolSetting(config.AcceptorTemplate)
+}
@@ -0,0 +1,120 @@
+package quickfix
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptorTemplateMatches(t *testing.T) {
+	templateID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "*", TargetSubID: "DESK[0-9]+"}
+	template, err := newAcceptorTemplate(templateID, NewSessionSettings())
+	require.Nil(t, err)
+
+	var tests = []struct {
+		sessionID SessionID
+		expected  bool
+	}{
+		{SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW", TargetSubID: "DESK1"}, true},
+		{SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "", TargetSubID: "DESK12"}, true},
+		{SessionID{BeginString: BeginStringFIX44, SenderCompID: "ISLD", TargetCompID: "TW", TargetSubID: "DESK1"}, false},
+		{SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLDX", TargetCompID: "TW", TargetSubID: "DESK1"}, false},
+		{SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW", TargetSubID: "DESK"}, false},
+		{SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW", TargetSubID: "XDESK1"}, false},
+		{SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW", TargetSubID: "DESK1", TargetLocationID: "NY"}, false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, template.matches(test.sessionID), "%v", test.sessionID)
+	}
+}
+
+func TestAcceptorTemplateInvalidPattern(t *testing.T) {
+	_, err := newAcceptorTemplate(SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "(TW"}, NewSessionSettings())
+	require.NotNil(t, err)
+
+	formatErr, ok := err.(IncorrectFormatForSetting)
+	require.True(t, ok)
+	assert.Equal(t, config.TargetCompID, formatErr.Setting)
+	assert.Equal(t, "(TW", formatErr.Value)
+}
+
+func TestSettingsAcceptorTemplates(t *testing.T) {
+	cfg := `
+[DEFAULT]
+ConnectionType=acceptor
+SenderCompID=ISLD
+FileStorePath=store
+
+[SESSION]
+BeginString=FIX.4.2
+TargetCompID=TW
+
+[SESSION]
+BeginString=FIX.4.2
+TargetCompID=CLIENT[0-9]+
+AcceptorTemplate=Y
+FileStorePath=clients
+
+[SESSION]
+BeginString=FIX.4.2
+TargetCompID=*
+AcceptorTemplate=Y
+`
+	settings, err := ParseSettings(strings.NewReader(cfg))
+	require.Nil(t, err)
+
+	assert.Len(t, settings.SessionSettings(), 1)
+
+	templateSettings := settings.AcceptorTemplateSettings()
+	require.Len(t, templateSettings, 2)
+	clientTemplate := templateSettings[SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "CLIENT[0-9]+"}]
+	require.NotNil(t, clientTemplate)
+	path, err := clientTemplate.Setting(config.FileStorePath)
+	require.Nil(t, err)
+	assert.Equal(t, "clients", path)
+
+	var tests = []struct {
+		targetCompID  string
+		expectedPath  string
+		expectedFound bool
+	}{
+		{"TW", "store", true},
+		{"CLIENT7", "clients", true},
+		{"OTHER", "store", true},
+	}
+
+	for _, test := range tests {
+		sessionID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: test.targetCompID}
+		sessionSettings, ok := settings.sessionSettingsFor(sessionID)
+		require.Equal(t, test.expectedFound, ok)
+
+		path, err := sessionSettings.Setting(config.FileStorePath)
+		require.Nil(t, err)
+		assert.Equal(t, test.expectedPath, path, test.targetCompID)
+	}
+
+	_, ok := settings.sessionSettingsFor(SessionID{BeginString: BeginStringFIX44, SenderCompID: "ISLD", TargetCompID: "OTHER"})
+	assert.False(t, ok)
+}
+
+func TestSettingsRejectDuplicateAcceptorTemplate(t *testing.T) {
+	settings := NewSettings()
+	for i := 0; i < 2; i++ {
+		template := NewSessionSettings()
+		template.Set(config.BeginString, BeginStringFIX42)
+		template.Set(config.SenderCompID, "ISLD")
+		template.Set(config.TargetCompID, "*")
+		template.Set(config.AcceptorTemplate, "Y")
+
+		_, err := settings.AddSession(template)
+		if i == 0 {
+			require.Nil(t, err)
+		} else {
+			require.NotNil(t, err)
+		}
+	}
+}
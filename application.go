@@ -19,8 +19,18 @@ type Application interface {
 	ToApp(message *Message, sessionID SessionID) error
 
 	//Notification of admin message being received from target.
+	//The message is only valid for the duration of the call, see Message.Retain.
 	FromAdmin(message *Message, sessionID SessionID) MessageRejectError
 
 	//Notification of app message being received from target.
+	//The message is only valid for the duration of the call, see Message.Retain.
 	FromApp(message *Message, sessionID SessionID) MessageRejectError
 }
+
+//PossResendApplication may optionally be implemented by an Application to be told whether an application message was
+//received with PossResend(97)=Y, meaning the message may have been sent before under a different MsgSeqNum and should be
+//checked against those already processed. FromAppPossResend is called instead of FromApp.
+type PossResendApplication interface {
+	//Notification of app message being received from target, possResend is true for PossResend(97)=Y.
+	FromAppPossResend(message *Message, sessionID SessionID, possResend bool) MessageRejectError
+}
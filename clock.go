@@ -0,0 +1,48 @@
+package quickfix
+
+import "github.com/quickfixgo/quickfix/internal"
+
+//Clock is the source of time and timers for sessions: sending times, latency checks, the session schedule, heartbeats
+//and timeouts. Sessions read the wall clock by default. A fake clock, such as clocktest.FakeClock, runs sessions in
+//virtual time. The message stores of this package stamp their creation time with the session clock, custom stores
+//read the wall clock.
+type Clock = internal.Clock
+
+//Timer is a single event created by Clock.AfterFunc
+type Timer = internal.Timer
+
+//Ticker delivers ticks at intervals, created by Clock.NewTicker
+type Ticker = internal.Ticker
+
+//sessionClock returns the clock for new sessions.
+func (f sessionFactory) sessionClock() Clock {
+	if f.clock == nil {
+		return internal.SystemClock{}
+	}
+
+	return f.clock
+}
+
+//setClock replaces the clock of the session and of its message store, if the store is one of this package.
+func (s *session) setClock(clock Clock) {
+	s.clock = clock
+	if store, ok := s.store.(clockedStore); ok {
+		store.setClock(clock)
+	}
+}
+
+//SetClock replaces the clock of the acceptor and its sessions. Must be called before Start.
+func (a *Acceptor) SetClock(clock Clock) {
+	a.clock = clock
+	for _, session := range a.sessions {
+		session.setClock(clock)
+	}
+}
+
+//SetClock replaces the clock of the initiator and its sessions. Must be called before Start.
+func (i *Initiator) SetClock(clock Clock) {
+	i.clock = clock
+	for _, session := range i.sessions {
+		session.setClock(clock)
+	}
+}
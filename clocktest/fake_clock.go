@@ -0,0 +1,174 @@
+//Package clocktest provides a deterministic Clock for testing sessions in virtual time.
+package clocktest
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/quickfixgo/quickfix/internal"
+)
+
+//FakeClock is a Clock whose time only moves when the test calls Advance or Set. Timers and tickers due by the new time
+//fire in deadline order, with the clock set to each deadline as it fires. Timer functions are called synchronously from
+//Advance and Set.
+type FakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	nextID int
+	timers map[int]*fakeTimer
+}
+
+//NewFakeClock returns a FakeClock set to now.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, timers: make(map[int]*fakeTimer)}
+}
+
+//Now implements Clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now
+}
+
+//AfterFunc implements Clock
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) internal.Timer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTimer{clock: c, f: f}
+	c.schedule(t, d)
+	return t
+}
+
+//NewTicker implements Clock
+func (c *FakeClock) NewTicker(d time.Duration) internal.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t := &fakeTicker{c: make(chan time.Time, 1)}
+	t.timer = &fakeTimer{clock: c, period: d}
+	t.timer.f = func() {
+		select {
+		case t.c <- c.Now():
+		default:
+		}
+	}
+	c.schedule(t.timer, d)
+	return t
+}
+
+//Advance moves the clock forward by d, firing any timers and tickers that fall due.
+func (c *FakeClock) Advance(d time.Duration) {
+	c.Set(c.Now().Add(d))
+}
+
+//Set moves the clock to now, firing any timers and tickers that fall due. Setting the clock backwards fires nothing.
+func (c *FakeClock) Set(now time.Time) {
+	for {
+		c.mu.Lock()
+		t := c.nextDue(now)
+		if t == nil {
+			if now.After(c.now) {
+				c.now = now
+			}
+			c.mu.Unlock()
+			return
+		}
+
+		if t.deadline.After(c.now) {
+			c.now = t.deadline
+		}
+
+		if t.period > 0 {
+			t.deadline = t.deadline.Add(t.period)
+		} else {
+			delete(c.timers, t.id)
+		}
+		c.mu.Unlock()
+
+		t.f()
+	}
+}
+
+//PendingTimers returns the number of active timers and tickers, allowing tests to wait until a session has armed its
+//timers before advancing the clock.
+func (c *FakeClock) PendingTimers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.timers)
+}
+
+func (c *FakeClock) schedule(t *fakeTimer, d time.Duration) {
+	c.nextID++
+	t.id = c.nextID
+	t.deadline = c.now.Add(d)
+	c.timers[t.id] = t
+}
+
+//nextDue returns the earliest timer due by now, ordered by deadline then creation.
+func (c *FakeClock) nextDue(now time.Time) *fakeTimer {
+	var due []*fakeTimer
+	for _, t := range c.timers {
+		if !t.deadline.After(now) {
+			due = append(due, t)
+		}
+	}
+
+	if len(due) == 0 {
+		return nil
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		if due[i].deadline.Equal(due[j].deadline) {
+			return due[i].id < due[j].id
+		}
+		return due[i].deadline.Before(due[j].deadline)
+	})
+	return due[0]
+}
+
+type fakeTimer struct {
+	clock    *FakeClock
+	id       int
+	deadline time.Time
+	period   time.Duration
+	f        func()
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	if _, ok := t.clock.timers[t.id]; !ok {
+		return false
+	}
+
+	delete(t.clock.timers, t.id)
+	return true
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+
+	_, active := t.clock.timers[t.id]
+	delete(t.clock.timers, t.id)
+	t.clock.schedule(t, d)
+	return active
+}
+
+type fakeTicker struct {
+	c     chan time.Time
+	timer *fakeTimer
+}
+
+func (t *fakeTicker) C() <-chan time.Time { return t.c }
+
+func (t *fakeTicker) Stop() { t.timer.Stop() }
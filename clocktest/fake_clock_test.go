@@ -0,0 +1,120 @@
+package clocktest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix/internal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClockAfterFunc(t *testing.T) {
+	start := time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	var fired []time.Time
+	c.AfterFunc(2*time.Second, func() { fired = append(fired, c.Now()) })
+	stopped := c.AfterFunc(time.Second, func() { t.Error("stopped timer should not fire") })
+	c.AfterFunc(time.Second, func() { fired = append(fired, c.Now()) })
+
+	assert.True(t, stopped.Stop())
+	assert.False(t, stopped.Stop())
+	assert.Equal(t, 2, c.PendingTimers())
+
+	c.Advance(500 * time.Millisecond)
+	assert.Empty(t, fired)
+
+	c.Advance(5 * time.Second)
+	assert.Equal(t, []time.Time{start.Add(time.Second), start.Add(2 * time.Second)}, fired)
+	assert.Equal(t, start.Add(5500*time.Millisecond), c.Now())
+	assert.Equal(t, 0, c.PendingTimers())
+}
+
+func TestFakeClockReset(t *testing.T) {
+	start := time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	count := 0
+	timer := c.AfterFunc(time.Second, func() { count++ })
+	c.Advance(900 * time.Millisecond)
+	assert.True(t, timer.Reset(time.Second))
+
+	c.Advance(900 * time.Millisecond)
+	assert.Equal(t, 0, count)
+
+	c.Advance(100 * time.Millisecond)
+	assert.Equal(t, 1, count)
+
+	assert.False(t, timer.Reset(time.Second), "timer should have fired")
+	c.Advance(time.Second)
+	assert.Equal(t, 2, count)
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	start := time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+
+	ticker := c.NewTicker(time.Second)
+	c.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ticker.C())
+
+	//ticks are dropped if not received, as with time.Ticker
+	c.Advance(3 * time.Second)
+	assert.Equal(t, start.Add(2*time.Second), <-ticker.C())
+	select {
+	case <-ticker.C():
+		t.Error("expected a single buffered tick")
+	default:
+	}
+
+	ticker.Stop()
+	c.Advance(time.Second)
+	select {
+	case <-ticker.C():
+		t.Error("stopped ticker should not tick")
+	default:
+	}
+}
+
+func TestEventTimerStopWhileFiring(t *testing.T) {
+	c := NewFakeClock(time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC))
+
+	//nothing receives events, like a session loop that has returned
+	events := make(chan struct{})
+	firing := make(chan struct{})
+
+	var timer *internal.EventTimer
+	timer = internal.NewEventTimer(c, func() {
+		close(firing)
+		select {
+		case events <- struct{}{}:
+		case <-timer.Done():
+		}
+	})
+	timer.Reset(time.Second)
+
+	advanced := make(chan struct{})
+	go func() {
+		c.Advance(time.Second)
+		close(advanced)
+	}()
+	<-firing
+
+	stopped := make(chan struct{})
+	go func() {
+		timer.Stop()
+		close(stopped)
+	}()
+
+	for _, done := range []chan struct{}{stopped, advanced} {
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("Stop should release a task blocked while firing")
+		}
+	}
+
+	timer.Reset(time.Second)
+	c.Advance(time.Second)
+	assert.Equal(t, 0, c.PendingTimers(), "stopped timer should not be rearmed")
+}
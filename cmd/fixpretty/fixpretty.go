@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+var (
+	dataDictionaryPath          = flag.String("dict", "", "Path to the data dictionary naming the fields of the messages.")
+	transportDataDictionaryPath = flag.String("transport-dict", "", "Path to the transport data dictionary naming the header and trailer fields of FIXT messages.")
+	color                       = flag.Bool("color", false, "Highlight the header, body and trailer of each message.")
+)
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %v [flags] [<path to message log> ...]\n", os.Args[0])
+	fmt.Fprintf(os.Stderr, "Formats FIX messages from message logs, or raw FIX read from stdin if no logs are given.\n")
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func parseDataDictionary(path string) (*datadictionary.DataDictionary, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	dict, err := datadictionary.Parse(path)
+	if err != nil {
+		return nil, fmt.Errorf("Error Parsing %v: %v", path, err)
+	}
+
+	return dict, nil
+}
+
+type printer struct {
+	out           *bufio.Writer
+	transportDict *datadictionary.DataDictionary
+	appDict       *datadictionary.DataDictionary
+	options       quickfix.FormatOptions
+}
+
+//print formats every message of r. Messages are found by their BeginString and may be delimited by SOH or, as
+//written by the file log, by '|'. Text preceding a message on a line, such as the timestamp of a log entry, and lines
+//without messages are copied as is.
+func (p printer) print(r io.Reader) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			p.printLine(bytes.TrimRight(line, "\r\n"))
+		}
+
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+func (p printer) printLine(line []byte) {
+	start := messageStart(line, 0)
+	if start < 0 {
+		fmt.Fprintf(p.out, "%s\n", line)
+		return
+	}
+
+	if prefix := bytes.TrimSpace(line[:start]); len(prefix) > 0 {
+		fmt.Fprintf(p.out, "%s\n", prefix)
+	}
+
+	for start >= 0 {
+		end := messageStart(line, start+1)
+		if end < 0 {
+			end = len(line)
+		}
+
+		p.printMessage(bytes.TrimSpace(line[start:end]))
+		start = messageStart(line, end)
+	}
+}
+
+func (p printer) printMessage(rawMsg []byte) {
+	rawMsg = bytes.Replace(rawMsg, []byte("|"), []byte("\x01"), -1)
+	if rawMsg[len(rawMsg)-1] != '\x01' {
+		rawMsg = append(rawMsg, '\x01')
+	}
+
+	msg := quickfix.NewMessage()
+	if err := quickfix.ParseMessageWithDataDictionary(msg, bytes.NewBuffer(rawMsg), p.transportDict, p.appDict); err != nil {
+		fmt.Fprintf(p.out, "%s\nError Parsing message: %v\n\n", bytes.Replace(rawMsg, []byte("\x01"), []byte("|"), -1), err)
+		return
+	}
+
+	fmt.Fprintf(p.out, "%v\n", quickfix.FormatMessage(msg, p.appDict, p.options))
+}
+
+//messageStart returns the index of the first BeginString field of line at or after from, or -1
+func messageStart(line []byte, from int) int {
+	for i := from; i < len(line); i++ {
+		if !bytes.HasPrefix(line[i:], []byte("8=FIX")) {
+			continue
+		}
+
+		if i == 0 || line[i-1] == ' ' || line[i-1] == '|' || line[i-1] == '\x01' {
+			return i
+		}
+	}
+
+	return -1
+}
+
+//run formats the logs given as arguments, or stdin, flushing the output written before any error
+func run() (err error) {
+	p := printer{
+		out:     bufio.NewWriter(os.Stdout),
+		options: quickfix.FormatOptions{Color: *color},
+	}
+	if p.transportDict, err = parseDataDictionary(*transportDataDictionaryPath); err != nil {
+		return err
+	}
+	if p.appDict, err = parseDataDictionary(*dataDictionaryPath); err != nil {
+		return err
+	}
+	if p.transportDict == nil {
+		p.transportDict = p.appDict
+	}
+	p.options.TransportDataDictionary = p.transportDict
+
+	defer func() {
+		if flushErr := p.out.Flush(); err == nil {
+			err = flushErr
+		}
+	}()
+
+	if flag.NArg() == 0 {
+		return p.print(os.Stdin)
+	}
+
+	for _, logPath := range flag.Args() {
+		file, err := os.Open(logPath)
+		if err != nil {
+			return err
+		}
+
+		err = p.print(file)
+		file.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+}
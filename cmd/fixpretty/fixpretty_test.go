@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	heartbeat = "8=FIX.4.2|9=45|35=0|34=2|49=TW|52=20060102-15:04:05|56=ISLD|10=215|"
+	logon     = "8=FIX.4.2|9=57|35=A|34=1|49=TW|52=20060102-15:04:05|56=ISLD|98=0|108=30|10=003|"
+)
+
+//formatted returns the output of printMessage for a '|' delimited message
+func formatted(t *testing.T, rawMsg string) string {
+	msg := quickfix.NewMessage()
+	require.Nil(t, quickfix.ParseMessage(msg, bytes.NewBufferString(strings.Replace(rawMsg, "|", "\x01", -1))))
+	return quickfix.FormatMessage(msg, nil, quickfix.FormatOptions{}) + "\n"
+}
+
+func printLine(line string) string {
+	var out bytes.Buffer
+	p := printer{out: bufio.NewWriter(&out)}
+	p.printLine([]byte(line))
+	p.out.Flush()
+	return out.String()
+}
+
+func TestMessageStart(t *testing.T) {
+	var tests = []struct {
+		name     string
+		line     string
+		from     int
+		expected int
+	}{
+		{"at line start", heartbeat, 0, 0},
+		{"after log timestamp", "2016/02/08 22:07:16.954 " + heartbeat, 0, 24},
+		{"after pipe", "x|" + heartbeat, 0, 2},
+		{"after SOH", "x\x01" + heartbeat, 0, 2},
+		{"inside a field value", "58=8=FIX.4.2 garbled", 0, -1},
+		{"suffix of a tag", "148=FIX", 0, -1},
+		{"no message", "session started", 0, -1},
+		{"from past the first message", heartbeat + logon, 1, len(heartbeat)},
+		{"from past the last message", heartbeat, 1, -1},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, messageStart([]byte(test.line), test.from), test.name)
+	}
+}
+
+func TestPrintLine(t *testing.T) {
+	var tests = []struct {
+		name     string
+		line     string
+		expected string
+	}{
+		{"no message", "session started", "session started\n"},
+		{"message", heartbeat, formatted(t, heartbeat)},
+		{"log entry", "2016/02/08 22:07:16.954 " + heartbeat, "2016/02/08 22:07:16.954\n" + formatted(t, heartbeat)},
+		{"pipe delimited messages", heartbeat + logon, formatted(t, heartbeat) + formatted(t, logon)},
+		{"space separated messages", heartbeat + " " + logon, formatted(t, heartbeat) + formatted(t, logon)},
+		{"SOH delimited messages", strings.Replace(heartbeat+logon, "|", "\x01", -1), formatted(t, heartbeat) + formatted(t, logon)},
+		{"message without trailing delimiter", strings.TrimSuffix(heartbeat, "|"), formatted(t, heartbeat)},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, printLine(test.line), test.name)
+	}
+}
+
+func TestPrintLineInvalidMessage(t *testing.T) {
+	out := printLine("8=FIX.4.2|9=5|garbled")
+	assert.True(t, strings.HasPrefix(out, "8=FIX.4.2|9=5|garbled|\nError Parsing message: "), out)
+}
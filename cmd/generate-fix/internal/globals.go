@@ -35,6 +35,11 @@ func BuildGlobalFieldTypes(specs []*datadictionary.DataDictionary) {
 	globalFieldTypesLookup = make(fieldTypeMap)
 	for _, spec := range specs {
 		for _, field := range spec.FieldTypeByTag {
+			//FIX 4.0 and 4.1 use char for string values
+			if field.Type == "CHAR" && spec.FIXType == "FIX" && spec.Major == 4 && spec.Minor < 2 {
+				field.Type = "STRING"
+			}
+
 			if oldField, ok := globalFieldTypesLookup[field.Name()]; ok {
 				//merge old enums with new
 				if len(oldField.Enums) > 0 && field.Enums == nil {
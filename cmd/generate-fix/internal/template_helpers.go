@@ -159,6 +159,16 @@ func quickfixValueType(quickfixType string) (goType string, err error) {
 		goType = "float64"
 	case "FIXDecimal":
 		goType = "decimal.Decimal"
+	case "FIXUTCDateOnly", "FIXUTCTimeOnly", "FIXLocalMktDate", "FIXTZTimestamp", "FIXTZTimeOnly":
+		goType = "time.Time"
+	case "FIXMonthYear":
+		goType = "quickfix.FIXMonthYear"
+	case "FIXMultipleValueString":
+		goType = "[]string"
+	case "FIXChar":
+		goType = "byte"
+	case "FIXData":
+		goType = "[]byte"
 	default:
 		err = fmt.Errorf("Unknown QuickFIX Type: %v", quickfixType)
 	}
@@ -171,16 +181,35 @@ func quickfixType(field *datadictionary.FieldType) (quickfixType string, err err
 	case "MULTIPLESTRINGVALUE", "MULTIPLEVALUESTRING":
 		fallthrough
 	case "MULTIPLECHARVALUE":
-		fallthrough
+		quickfixType = "FIXMultipleValueString"
+
 	case "CHAR":
-		fallthrough
-	case "CURRENCY":
-		fallthrough
+		quickfixType = "FIXChar"
+
 	case "DATA":
-		fallthrough
+		quickfixType = "FIXData"
+
 	case "MONTHYEAR":
-		fallthrough
+		quickfixType = "FIXMonthYear"
+
 	case "LOCALMKTDATE":
+		quickfixType = "FIXLocalMktDate"
+
+	case "UTCTIMEONLY":
+		quickfixType = "FIXUTCTimeOnly"
+
+	case "UTCDATE":
+		fallthrough
+	case "UTCDATEONLY":
+		quickfixType = "FIXUTCDateOnly"
+
+	case "TZTIMEONLY":
+		quickfixType = "FIXTZTimeOnly"
+
+	case "TZTIMESTAMP":
+		quickfixType = "FIXTZTimestamp"
+
+	case "CURRENCY":
 		fallthrough
 	case "TIME":
 		fallthrough
@@ -194,16 +223,6 @@ func quickfixType(field *datadictionary.FieldType) (quickfixType string, err err
 		fallthrough
 	case "COUNTRY":
 		fallthrough
-	case "UTCTIMEONLY":
-		fallthrough
-	case "UTCDATE":
-		fallthrough
-	case "UTCDATEONLY":
-		fallthrough
-	case "TZTIMEONLY":
-		fallthrough
-	case "TZTIMESTAMP":
-		fallthrough
 	case "STRING":
 		quickfixType = "FIXString"
 
@@ -345,6 +345,23 @@ func New{{ .Name }}WithPrecision(val time.Time, precision quickfix.TimestampPrec
 	return {{ .Name }}Field{ quickfix.FIXUTCTimestamp{ Time: val, Precision: precision } }
 }
 
+{{ else if or (eq $base_type "FIXUTCTimeOnly") (eq $base_type "FIXTZTimestamp") (eq $base_type "FIXTZTimeOnly") }}
+// New{{ .Name }} returns a new {{ .Name }}Field initialized with val.
+func New{{ .Name }}(val time.Time) {{ .Name }}Field {
+	return New{{ .Name }}WithPrecision(val, quickfix.Millis)
+}
+
+// New{{ .Name }}WithPrecision returns a new {{ .Name }}Field initialized with val of specified precision.
+func New{{ .Name }}WithPrecision(val time.Time, precision quickfix.TimestampPrecision) {{ .Name }}Field {
+	return {{ .Name }}Field{ quickfix.{{ $base_type }}{ Time: val, Precision: precision } }
+}
+
+{{ else if or (eq $base_type "FIXUTCDateOnly") (eq $base_type "FIXLocalMktDate") }}
+// New{{ .Name }} returns a new {{ .Name }}Field initialized with val.
+func New{{ .Name }}(val time.Time) {{ .Name }}Field {
+	return {{ .Name }}Field{ quickfix.{{ $base_type }}{ Time: val } }
+}
+
 {{ else if and  .Enums (ne $base_type "FIXBoolean") }}
 func New{{ .Name }}(val enum.{{ .Name }}) {{ .Name }}Field {
 	return {{ .Name }}Field{ quickfix.FIXString(val) }
@@ -377,6 +394,16 @@ func (f {{ .Name }}Field) Value() ({{ quickfixValueType $base_type }}) {
  return f.Time }
 {{- else if eq $base_type "FIXFloat" -}}
  return f.Float() }
+{{- else if or (eq $base_type "FIXUTCDateOnly") (eq $base_type "FIXUTCTimeOnly") (eq $base_type "FIXLocalMktDate") (eq $base_type "FIXTZTimestamp") (eq $base_type "FIXTZTimeOnly") -}}
+ return f.Time }
+{{- else if eq $base_type "FIXMonthYear" -}}
+ return f.FIXMonthYear }
+{{- else if eq $base_type "FIXMultipleValueString" -}}
+ return []string(f.FIXMultipleValueString) }
+{{- else if eq $base_type "FIXChar" -}}
+ return byte(f.FIXChar) }
+{{- else if eq $base_type "FIXData" -}}
+ return []byte(f.FIXData) }
 {{- else -}}
  TEMPLATE ERROR: Value() for {{ $base_type }}
 {{ end }}{{ end }}{{ end }}
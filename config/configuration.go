@@ -4,62 +4,89 @@ package config
 
 //Const configuration settings
 const (
-	BeginString                  string = "BeginString"
-	SenderCompID                 string = "SenderCompID"
-	SenderSubID                  string = "SenderSubID"
-	SenderLocationID             string = "SenderLocationID"
-	TargetCompID                 string = "TargetCompID"
-	TargetSubID                  string = "TargetSubID"
-	TargetLocationID             string = "TargetLocationID"
-	SessionQualifier             string = "SessionQualifier"
-	SocketAcceptHost             string = "SocketAcceptHost"
-	SocketAcceptPort             string = "SocketAcceptPort"
-	SocketConnectHost            string = "SocketConnectHost"
-	SocketConnectPort            string = "SocketConnectPort"
-	SocketPrivateKeyFile         string = "SocketPrivateKeyFile"
-	SocketCertificateFile        string = "SocketCertificateFile"
-	SocketCAFile                 string = "SocketCAFile"
-	SocketInsecureSkipVerify     string = "SocketInsecureSkipVerify"
-	SocketServerName             string = "SocketServerName"
-	SocketMinimumTLSVersion      string = "SocketMinimumTLSVersion"
-	SocketTimeout                string = "SocketTimeout"
-	SocketUseSSL                 string = "SocketUseSSL"
-	ProxyType                    string = "ProxyType"
-	ProxyHost                    string = "ProxyHost"
-	ProxyPort                    string = "ProxyPort"
-	ProxyUser                    string = "ProxyUser"
-	ProxyPassword                string = "ProxyPassword"
-	DefaultApplVerID             string = "DefaultApplVerID"
-	StartTime                    string = "StartTime"
-	EndTime                      string = "EndTime"
-	StartDay                     string = "StartDay"
-	EndDay                       string = "EndDay"
-	TimeZone                     string = "TimeZone"
-	DataDictionary               string = "DataDictionary"
-	TransportDataDictionary      string = "TransportDataDictionary"
-	AppDataDictionary            string = "AppDataDictionary"
-	ResetOnLogon                 string = "ResetOnLogon"
-	RefreshOnLogon               string = "RefreshOnLogon"
-	ResetOnLogout                string = "ResetOnLogout"
-	ResetOnDisconnect            string = "ResetOnDisconnect"
-	ReconnectInterval            string = "ReconnectInterval"
-	LogoutTimeout                string = "LogoutTimeout"
-	LogonTimeout                 string = "LogonTimeout"
-	HeartBtInt                   string = "HeartBtInt"
-	FileLogPath                  string = "FileLogPath"
-	FileStorePath                string = "FileStorePath"
-	SQLStoreDriver               string = "SQLStoreDriver"
-	SQLStoreDataSourceName       string = "SQLStoreDataSourceName"
-	SQLStoreConnMaxLifetime      string = "SQLStoreConnMaxLifetime"
-	MongoStoreConnection         string = "MongoStoreConnection"
-	MongoStoreDatabase           string = "MongoStoreDatabase"
-	ValidateFieldsOutOfOrder     string = "ValidateFieldsOutOfOrder"
-	ResendRequestChunkSize       string = "ResendRequestChunkSize"
-	EnableLastMsgSeqNumProcessed string = "EnableLastMsgSeqNumProcessed"
-	CheckLatency                 string = "CheckLatency"
-	TimeStampPrecision           string = "TimeStampPrecision"
-	MaxLatency                   string = "MaxLatency"
-	PersistMessages              string = "PersistMessages"
-	RejectInvalidMessage         string = "RejectInvalidMessage"
-	DynamicSessions              string = "DynamicSessions"
+	BeginString                     string = "BeginString"
+	SenderCompID                    string = "SenderCompID"
+	SenderSubID                     string = "SenderSubID"
+	SenderLocationID                string = "SenderLocationID"
+	TargetCompID                    string = "TargetCompID"
+	TargetSubID                     string = "TargetSubID"
+	TargetLocationID                string = "TargetLocationID"
+	SessionQualifier                string = "SessionQualifier"
+	SocketAcceptHost                string = "SocketAcceptHost"
+	SocketAcceptPort                string = "SocketAcceptPort"
+	SocketConnectHost               string = "SocketConnectHost"
+	SocketConnectPort               string = "SocketConnectPort"
+	SocketPrivateKeyFile            string = "SocketPrivateKeyFile"
+	SocketCertificateFile           string = "SocketCertificateFile"
+	SocketCAFile                    string = "SocketCAFile"
+	SocketInsecureSkipVerify        string = "SocketInsecureSkipVerify"
+	SocketServerName                string = "SocketServerName"
+	SocketMinimumTLSVersion         string = "SocketMinimumTLSVersion"
+	SocketTimeout                   string = "SocketTimeout"
+	SocketUseSSL                    string = "SocketUseSSL"
+	ProxyType                       string = "ProxyType"
+	ProxyHost                       string = "ProxyHost"
+	ProxyPort                       string = "ProxyPort"
+	ProxyUser                       string = "ProxyUser"
+	ProxyPassword                   string = "ProxyPassword"
+	DefaultApplVerID                string = "DefaultApplVerID"
+	StartTime                       string = "StartTime"
+	EndTime                         string = "EndTime"
+	StartDay                        string = "StartDay"
+	EndDay                          string = "EndDay"
+	TimeZone                        string = "TimeZone"
+	DataDictionary                  string = "DataDictionary"
+	TransportDataDictionary         string = "TransportDataDictionary"
+	AppDataDictionary               string = "AppDataDictionary"
+	ResetOnLogon                    string = "ResetOnLogon"
+	RefreshOnLogon                  string = "RefreshOnLogon"
+	ResetOnLogout                   string = "ResetOnLogout"
+	ResetOnDisconnect               string = "ResetOnDisconnect"
+	ReconnectInterval               string = "ReconnectInterval"
+	LogoutTimeout                   string = "LogoutTimeout"
+	LogonTimeout                    string = "LogonTimeout"
+	HeartBtInt                      string = "HeartBtInt"
+	FileLogPath                     string = "FileLogPath"
+	FileStorePath                   string = "FileStorePath"
+	SQLStoreDriver                  string = "SQLStoreDriver"
+	SQLStoreDataSourceName          string = "SQLStoreDataSourceName"
+	SQLStoreConnMaxLifetime         string = "SQLStoreConnMaxLifetime"
+	MongoStoreConnection            string = "MongoStoreConnection"
+	MongoStoreDatabase              string = "MongoStoreDatabase"
+	ValidateFieldsOutOfOrder        string = "ValidateFieldsOutOfOrder"
+	ResendRequestChunkSize          string = "ResendRequestChunkSize"
+	ResendRequestEndSeqNoStyle      string = "ResendRequestEndSeqNoStyle"
+	ResendRequestTimeout            string = "ResendRequestTimeout"
+	MaxResendRequestRetries         string = "MaxResendRequestRetries"
+	MaxMessageStashSize             string = "MaxMessageStashSize"
+	EnableLastMsgSeqNumProcessed    string = "EnableLastMsgSeqNumProcessed"
+	EnableNextExpectedMsgSeqNum     string = "EnableNextExpectedMsgSeqNum"
+	SessionComplianceLevel          string = "SessionComplianceLevel"
+	CheckLatency                    string = "CheckLatency"
+	TimeStampPrecision              string = "TimeStampPrecision"
+	MaxLatency                      string = "MaxLatency"
+	PersistMessages                 string = "PersistMessages"
+	RejectInvalidMessage            string = "RejectInvalidMessage"
+	DynamicSessions                 string = "DynamicSessions"
+	LogonUsername                   string = "LogonUsername"
+	LogonPassword                   string = "LogonPassword"
+	LogonNewPassword                string = "LogonNewPassword"
+	LogonCredentialsFile            string = "LogonCredentialsFile"
+	AllowedRemoteAddresses          string = "AllowedRemoteAddresses"
+	MaxConnectionsPerRemote         string = "MaxConnectionsPerRemote"
+	AcceptorMaxConnections          string = "AcceptorMaxConnections"
+	AcceptorFirstMessageTimeout     string = "AcceptorFirstMessageTimeout"
+	AcceptorTemplate                string = "AcceptorTemplate"
+	MaxDynamicSessions              string = "MaxDynamicSessions"
+	DynamicSessionLinger            string = "DynamicSessionLinger"
+	SessionCalendarFile             string = "SessionCalendarFile"
+	LogonTime                       string = "LogonTime"
+	LogoutTime                      string = "LogoutTime"
+	LogonDay                        string = "LogonDay"
+	LogoutDay                       string = "LogoutDay"
+	TestRequestDelayMultiplier      string = "TestRequestDelayMultiplier"
+	HeartbeatTimeoutMultiplier      string = "HeartbeatTimeoutMultiplier"
+	MaxTestRequestsBeforeDisconnect string = "MaxTestRequestsBeforeDisconnect"
+	MinHeartBtInt                   string = "MinHeartBtInt"
+	MaxHeartBtInt                   string = "MaxHeartBtInt"
 )
@@ -82,6 +82,17 @@ Time of day that this FIX session becomes deactivated. Valid Values:
 
  time in the format of HH:MM:SS, time is represented in time zone configured by TimeZone
 
+Multiple Session Windows
+
+Venues running several sessions a day are configured with comma separated lists of StartTime and EndTime values, e.g.
+
+ StartTime=07:00:00,13:30:00
+ EndTime=12:00:00,20:00:00
+
+The windows on a given day of week may be overridden by prefixing StartTime and EndTime with the full English day name, e.g. MondayStartTime and MondayEndTime. Days without an override use StartTime and EndTime, or have no session if those are not configured. Each window is a separate session, and the message store is reset between windows. Windows may not span midnight or overlap, and StartDay and EndDay cannot be used with multiple windows.
+
+LogonTime and LogoutTime accept multiple windows and day overrides (e.g. MondayLogonTime) in the same way. To keep the same session across several windows a day, configure the windows with LogonTime and LogoutTime within a single StartTime and EndTime.
+
 StartDay
 
 For week long sessions, the starting day of week for the session. Use in combination with StartTime. Valid Values:
@@ -94,6 +105,34 @@ For week long sessions, the ending day of week for the session. Use in combinati
 
  Full day of week in English, or 3 letter abbreviation (i.e. Monday and Mon are valid)
 
+LogonTime
+
+Time of day from which the session may log on. Outside of the logon window initiators do not connect, acceptors refuse logons, and a connected session is disconnected, but unlike StartTime and EndTime the message store is not reset. Use with StartTime and EndTime to keep the same session, and sequence numbers, across disconnects, e.g. a weekly session logging on each trading day. Valid Values:
+
+ time in the format of HH:MM:SS, time is represented in time zone configured by TimeZone
+
+LogoutTime
+
+Time of day after which the session may not be logged on. Use in combination with LogonTime. Valid Values:
+
+ time in the format of HH:MM:SS, time is represented in time zone configured by TimeZone
+
+LogonDay
+
+For week long logon windows, the starting day of week of the logon window. Use in combination with LogonTime. Valid Values:
+
+ Full day of week in English, or 3 letter abbreviation (i.e. Monday and Mon are valid)
+
+LogoutDay
+
+For week long logon windows, the ending day of week of the logon window. Use in combination with LogoutTime. Valid Values:
+
+ Full day of week in English, or 3 letter abbreviation (i.e. Monday and Mon are valid)
+
+SessionCalendarFile
+
+Path to a holiday calendar of dates on which there is no session, e.g. exchange holidays. Times falling on a listed date, in the session TimeZone, are not session time, and a listed date within a week long session ends that session. The file is either a list of dates in the format YYYY-MM-DD, one per line, with blank lines and lines beginning with # ignored, or an iCalendar (ICS) file of all day events. Applies to both the StartTime/EndTime session window and the LogonTime/LogoutTime logon window.
+
 EnableLastMsgSeqNumProcessed
 
 Add the last message sequence number processed in the header (optional tag 369).  Valid Values:
@@ -102,10 +141,39 @@ Add the last message sequence number processed in the header (optional tag 369).
 
 Defaults to N.
 
+EnableNextExpectedMsgSeqNum
+
+Add the next expected message sequence number to the Logon (optional tag 789, FIX.4.4 and later) and recover sequence gaps with the Logon itself. On receipt of a Logon containing tag 789, messages from that sequence number are resent or gap filled without waiting for a ResendRequest. A Logon with tag 789 greater than the next sequence number to be sent is rejected with a Logout. When both sides send tag 789, no ResendRequest is sent for a Logon received with a sequence number higher than expected; the counterparty is expected to resend the missing messages. Valid Values:
+ Y
+ N
+
+Defaults to N.
+
 ResendRequestChunkSize
 
 Setting to limit the size of a resend request in case of missing messages. This is useful when the remote FIX engine does not allow to ask for more than n message for a ResendRequest.  E.g. if the ResendRequestChunkSize is set to 5 and a gap of 7 messages is detected, a first resend request will be sent for 5 messages. When this gap has been filled, another resend request for 2 messages will be sent. If the ResendRequestChunkSize is set to 0, only one ResendRequest for all the missing messages will be sent. Value must be positive integer. Defaults to 0 (disables splitting).
 
+ResendRequestEndSeqNoStyle
+
+Determines the EndSeqNo(16) of a ResendRequest for all missing messages, and of the last ResendRequest when splitting with ResendRequestChunkSize. ResendRequests received from the counterparty are accepted in any of these forms. Valid Values:
+ zero (EndSeqNo is 0)
+ infinity (EndSeqNo is 999999)
+ explicit (EndSeqNo is the last missing sequence number)
+
+Defaults to 0 for FIX.4.2 and later, 999999 for earlier versions.
+
+ResendRequestTimeout
+
+Time in seconds to wait for the counterparty to make progress filling a sequence gap after a ResendRequest. When the timeout elapses without the next expected message, the ResendRequest is sent again for the messages still missing, up to MaxResendRequestRetries times. Value must be a non-negative integer. Defaults to 0 (wait indefinitely).
+
+MaxResendRequestRetries
+
+Number of times a ResendRequest is sent again after ResendRequestTimeout before the session is logged out. Value must be a non-negative integer. Defaults to 3.
+
+MaxMessageStashSize
+
+Maximum number of messages received ahead of a sequence gap that are held while the gap is filled. Receiving more messages than this while resending logs out the session. Value must be a positive integer. Defaults to no limit.
+
 ResetOnLogon
 
 Determines if sequence numbers should be reset when receiving a logon request. Acceptors only.	Valid Values:
@@ -140,14 +208,32 @@ Defaults to N.
 
 TimeStampPrecision
 
-Determines precision for timestamps in (Orig)SendingTime fields that are sent out. Only available for FIX.4.2 and greater, FIX versions earlier than FIX.4.2 will use timestamp resolution in seconds. Valid Values:
+Determines precision for timestamps in (Orig)SendingTime fields that are sent out. Only available for FIX.4.2 and greater, FIX versions earlier than FIX.4.2 will use timestamp resolution in seconds. Received timestamps are accepted with any precision up to PICOS, whatever the setting. Valid Values:
  SECONDS
  MILLIS
  MICROS
  NANOS
+ PICOS
 
 Defaults to MILLIS.
 
+SessionComplianceLevel
+
+Determines how strictly the session layer rules of the FIX protocol are applied to messages from the counterparty. Valid Values:
+ standard
+ strict
+ lenient
+
+With standard, a possible duplicate received with a MsgSeqNum lower than expected must include OrigSendingTime(122) no later than SendingTime(52), and a SequenceReset with NewSeqNo(36) less than the expected MsgSeqNum is rejected.
+
+With strict, every message with PossDupFlag(43)=Y must include OrigSendingTime no later than SendingTime. A missing OrigSendingTime is rejected, and an OrigSendingTime after SendingTime is rejected followed by a Logout.
+
+With lenient, the standard checks apply with the following relaxations:
+ a possible duplicate received with a MsgSeqNum lower than expected and no OrigSendingTime is ignored rather than rejected
+ an invalid PossResend(97) is passed to the application as N rather than rejected
+
+Defaults to standard.
+
 Validation
 
 The following settings are specific to message validation.
@@ -246,6 +332,42 @@ HeartBtInt
 
 Heartbeat interval in seconds. Only used for initiators.	Value must be positive integer.
 
+TestRequestDelayMultiplier
+
+Time without receiving any message, as a multiple of the heartbeat interval, after which a TestRequest is sent to the counterparty. Value must be a positive number. Defaults to 1.2.
+
+HeartbeatTimeoutMultiplier
+
+Time to wait for a reply to each TestRequest, as a multiple of the heartbeat interval. Value must be a positive number. Defaults to 1.2.
+
+MinHeartBtInt
+
+Minimum heartbeat interval in seconds accepted in a Logon request. Logons with a lower HeartBtInt (tag 108) are rejected with a Logout explaining the reason. A HeartBtInt of 0 disables heartbeats and supervision of the counterparty; set MinHeartBtInt to at least 1 to refuse such logons. Only used for acceptors. Value must be a non-negative integer. Defaults to 0.
+
+MaxHeartBtInt
+
+Maximum heartbeat interval in seconds accepted in a Logon request. Logons with a higher HeartBtInt (tag 108) are rejected with a Logout explaining the reason. Only used for acceptors. Value must be a positive integer, not less than MinHeartBtInt. Defaults to no maximum.
+
+MaxTestRequestsBeforeDisconnect
+
+Number of unanswered TestRequests sent before the session is disconnected. Each TestRequest carries a unique TestReqID (tag 112), matched against the TestReqID of the Heartbeat reply. Value must be a positive integer. Defaults to 1.
+
+LogonUsername
+
+Value of Username (tag 553) sent in the Logon message. Only used for initiators.
+
+LogonPassword
+
+Value of Password (tag 554) sent in the Logon message. Only used for initiators. The password is masked in message logs.
+
+LogonNewPassword
+
+Value of NewPassword (tag 925) sent in the Logon message to rotate the session password. Only used for initiators. The new password is masked in message logs.
+
+LogonCredentialsFile
+
+File containing LogonUsername, LogonPassword and LogonNewPassword settings, one per line. The file is read each time a Logon is sent, so credentials may be rotated without a restart. Takes precedence over LogonUsername, LogonPassword and LogonNewPassword. Only used for initiators.
+
 SocketConnectPort
 
 Socket port for connecting to a session. Only used for initiators. Must be positive integer
@@ -280,6 +402,48 @@ SocketAcceptPort
 
 Socket port for listening to incoming connections, only used for acceptors. Value must be a positive integer, valid open socket port.
 
+AllowedRemoteAddresses
+
+Comma separated list of CIDR blocks or IP addresses permitted to connect to this session, e.g. 10.1.0.0/16,192.168.5.20. Connections from other addresses are closed once the session is identified from the first message. Only used for acceptors. Defaults to allowing all addresses.
+
+MaxConnectionsPerRemote
+
+Maximum number of concurrent connections from a single remote host that may be open when this session is identified. Further connections are closed. Only used for acceptors. Value must be a positive integer. Defaults to 0 (no limit).
+
+AcceptorMaxConnections
+
+Maximum number of concurrent connections accepted across all sessions. Connections beyond the limit are closed immediately. Only used for acceptors, in the DEFAULT section. Value must be a positive integer. Defaults to 0 (no limit).
+
+AcceptorFirstMessageTimeout
+
+Duration an acceptor waits for the first message (and TLS handshake) of a new connection before closing it. Only used for acceptors, in the DEFAULT section.
+
+Example Values:
+ AcceptorFirstMessageTimeout=10s # 10 seconds
+
+Defaults to 0 (wait indefinitely).
+
+AcceptorTemplate
+
+Marks the session definition as a template for dynamically created acceptor sessions. SenderCompID, SenderSubID, SenderLocationID, TargetCompID, TargetSubID and TargetLocationID may be * to match any value, or a regular expression matching the whole value. An omitted field only matches sessions without that field. BeginString must match exactly. When a logon is received for a session that is not configured, the first template that matches, in the order templates are declared, is used to create the session, which inherits all settings of the template, e.g. DataDictionary, FileStorePath and the session schedule. An Application implementing AcceptorTemplateMatcher may further approve or refuse each matched session. Only used for acceptors. Valid Values:
+ Y
+ N
+
+Defaults to N.
+
+MaxDynamicSessions
+
+Maximum number of dynamic sessions, created with DynamicSessions or from an AcceptorTemplate, that may exist at once. Logons for further sessions are refused. Only used for acceptors, in the DEFAULT section. Value must be a positive integer. Defaults to 0 (no limit).
+
+DynamicSessionLinger
+
+Duration a dynamic session remains registered after its connection closes. A counterparty reconnecting within this time resumes the same session, and messages sent to the session in the meantime are queued. Only used for acceptors, in the DEFAULT section.
+
+Example Values:
+ DynamicSessionLinger=30s # 30 seconds
+
+Defaults to 0 (unregister as soon as the connection closes).
+
 SocketPrivateKeyFile
 
 Private key to use for secure TLS connections.  Must be used with SocketCertificateFile.
@@ -12,6 +12,8 @@ func writeLoop(connection io.Writer, messageOut chan []byte, log Log) {
 		if _, err := connection.Write(msg); err != nil {
 			log.OnEvent(err.Error())
 		}
+
+		putOutboundBuffer(msg)
 	}
 }
 
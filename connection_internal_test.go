@@ -25,6 +25,24 @@ func TestWriteLoop(t *testing.T) {
 	}
 }
 
+func TestWriteLoopRecyclesBuffers(t *testing.T) {
+	for len(outboundBuffers) > 0 {
+		<-outboundBuffers
+	}
+
+	msgOut := make(chan []byte, 1)
+	msg := append(getOutboundBuffer(), "test msg"...)
+	msgOut <- msg
+	close(msgOut)
+
+	writeLoop(bytes.NewBufferString(""), msgOut, nullLog{})
+
+	recycled := getOutboundBuffer()
+	if len(recycled) != 0 || &recycled[:1][0] != &msg[0] {
+		t.Error("expected the written buffer to be recycled")
+	}
+}
+
 func TestReadLoop(t *testing.T) {
 	msgIn := make(chan fixIn)
 	stream := "hello8=FIX.4.09=5blah10=103garbage8=FIX.4.09=4foo10=103"
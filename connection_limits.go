@@ -0,0 +1,157 @@
+package quickfix
+
+import (
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/quickfixgo/quickfix/config"
+)
+
+//connectionTracker counts open acceptor connections, in total and by remote host.
+type connectionTracker struct {
+	sync.Mutex
+	maxConnections int
+	total          int
+	byRemote       map[string]int
+}
+
+func newConnectionTracker(maxConnections int) *connectionTracker {
+	return &connectionTracker{maxConnections: maxConnections, byRemote: make(map[string]int)}
+}
+
+//add records a new connection. Returns false if the connection would exceed the configured maximum.
+func (t *connectionTracker) add(remote string) bool {
+	t.Lock()
+	defer t.Unlock()
+
+	if t.maxConnections > 0 && t.total >= t.maxConnections {
+		return false
+	}
+
+	t.total++
+	t.byRemote[remote]++
+	return true
+}
+
+func (t *connectionTracker) remove(remote string) {
+	t.Lock()
+	defer t.Unlock()
+
+	t.total--
+	if t.byRemote[remote]--; t.byRemote[remote] <= 0 {
+		delete(t.byRemote, remote)
+	}
+}
+
+//remoteCount returns the number of open connections from the remote host.
+func (t *connectionTracker) remoteCount(remote string) int {
+	t.Lock()
+	defer t.Unlock()
+
+	return t.byRemote[remote]
+}
+
+//remoteHost returns the host portion of a remote address, used to group connections by peer.
+func remoteHost(addr net.Addr) string {
+	if tcpAddr, ok := addr.(*net.TCPAddr); ok {
+		return tcpAddr.IP.String()
+	}
+
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+//parseAllowedRemoteAddresses parses a comma separated list of CIDR blocks or IP addresses.
+func parseAllowedRemoteAddresses(value string) ([]*net.IPNet, error) {
+	var allowed []*net.IPNet
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, IncorrectFormatForSetting{Setting: config.AllowedRemoteAddresses, Value: value}
+			}
+
+			if ip4 := ip.To4(); ip4 != nil {
+				allowed = append(allowed, &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)})
+			} else {
+				allowed = append(allowed, &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)})
+			}
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, IncorrectFormatForSetting{Setting: config.AllowedRemoteAddresses, Value: value, Err: err}
+		}
+		allowed = append(allowed, ipNet)
+	}
+
+	return allowed, nil
+}
+
+//remoteLimits restricts the remote hosts that may connect to an acceptor session.
+type remoteLimits struct {
+	allowedRemoteAddresses  []*net.IPNet
+	maxConnectionsPerRemote int
+}
+
+//parseRemoteLimits reads the AllowedRemoteAddresses and MaxConnectionsPerRemote settings of an acceptor session.
+func parseRemoteLimits(settings *SessionSettings) (limits remoteLimits, err error) {
+	if settings.HasSetting(config.AllowedRemoteAddresses) {
+		var allowed string
+		if allowed, err = settings.Setting(config.AllowedRemoteAddresses); err != nil {
+			return
+		}
+
+		if limits.allowedRemoteAddresses, err = parseAllowedRemoteAddresses(allowed); err != nil {
+			return
+		}
+	}
+
+	if settings.HasSetting(config.MaxConnectionsPerRemote) {
+		if limits.maxConnectionsPerRemote, err = settings.IntSetting(config.MaxConnectionsPerRemote); err != nil {
+			return
+		}
+
+		if limits.maxConnectionsPerRemote <= 0 {
+			err = errors.New("MaxConnectionsPerRemote must be greater than zero")
+			return
+		}
+	}
+
+	return
+}
+
+//isAllowed returns true if addr is in the allowed remote addresses, or no addresses are configured.
+func (l remoteLimits) isAllowed(addr net.Addr) bool {
+	if len(l.allowedRemoteAddresses) == 0 {
+		return true
+	}
+
+	ip := net.ParseIP(remoteHost(addr))
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range l.allowedRemoteAddresses {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *session) remoteLimits() remoteLimits {
+	return remoteLimits{allowedRemoteAddresses: s.AllowedRemoteAddresses, maxConnectionsPerRemote: s.MaxConnectionsPerRemote}
+}
@@ -0,0 +1,169 @@
+package quickfix
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionTracker(t *testing.T) {
+	tracker := newConnectionTracker(2)
+
+	assert.True(t, tracker.add("10.0.0.1"))
+	assert.True(t, tracker.add("10.0.0.1"))
+	assert.False(t, tracker.add("10.0.0.2"), "should refuse connections beyond the maximum")
+	assert.Equal(t, 2, tracker.remoteCount("10.0.0.1"))
+	assert.Equal(t, 0, tracker.remoteCount("10.0.0.2"))
+
+	tracker.remove("10.0.0.1")
+	assert.Equal(t, 1, tracker.remoteCount("10.0.0.1"))
+	assert.True(t, tracker.add("10.0.0.2"))
+
+	unlimited := newConnectionTracker(0)
+	for i := 0; i < 100; i++ {
+		assert.True(t, unlimited.add("10.0.0.1"))
+	}
+}
+
+func TestRemoteHost(t *testing.T) {
+	assert.Equal(t, "10.0.0.1", remoteHost(&net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5001}))
+	assert.Equal(t, "::1", remoteHost(&net.TCPAddr{IP: net.ParseIP("::1"), Port: 5001}))
+}
+
+func TestParseAllowedRemoteAddresses(t *testing.T) {
+	allowed, err := parseAllowedRemoteAddresses("10.1.0.0/16, 192.168.5.20,::1")
+	require.Nil(t, err)
+	require.Len(t, allowed, 3)
+	assert.Equal(t, "10.1.0.0/16", allowed[0].String())
+	assert.Equal(t, "192.168.5.20/32", allowed[1].String())
+	assert.Equal(t, "::1/128", allowed[2].String())
+
+	_, err = parseAllowedRemoteAddresses("10.1.0.0/33")
+	assert.NotNil(t, err)
+
+	_, err = parseAllowedRemoteAddresses("not an address")
+	assert.NotNil(t, err)
+}
+
+func TestAcceptorRefusesRemote(t *testing.T) {
+	cfg := `
+[DEFAULT]
+ConnectionType=acceptor
+SenderCompID=REMOTES
+HeartBtInt=30
+BeginString=FIX.4.2
+
+[SESSION]
+TargetCompID=ANY
+
+[SESSION]
+TargetCompID=RESTRICTED
+AllowedRemoteAddresses=10.1.0.0/16,192.168.5.20
+`
+	settings, err := ParseSettings(strings.NewReader(cfg))
+	require.Nil(t, err)
+
+	a, err := NewAcceptor(new(MockApp), NewMemoryStoreFactory(), settings, nullLogFactory{})
+	require.Nil(t, err)
+
+	anyID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "REMOTES", TargetCompID: "ANY"}
+	restrictedID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "REMOTES", TargetCompID: "RESTRICTED"}
+	defer func() {
+		_ = UnregisterSession(anyID)
+		_ = UnregisterSession(restrictedID)
+	}()
+
+	addr := &net.TCPAddr{IP: net.ParseIP("172.16.0.1"), Port: 5001}
+	assert.False(t, a.refusesRemote(anyID, addr, a.sessions[anyID].remoteLimits()), "all remotes allowed by default")
+
+	var tests = []struct {
+		ip      string
+		allowed bool
+	}{
+		{"10.1.200.3", true},
+		{"10.2.0.1", false},
+		{"192.168.5.20", true},
+		{"192.168.5.21", false},
+	}
+
+	for _, test := range tests {
+		addr := &net.TCPAddr{IP: net.ParseIP(test.ip), Port: 5001}
+		assert.Equal(t, !test.allowed, a.refusesRemote(restrictedID, addr, a.sessions[restrictedID].remoteLimits()), test.ip)
+	}
+}
+
+func TestAcceptorFirstMessageTimeout(t *testing.T) {
+	a := &Acceptor{globalLog: nullLog{}, firstMsgTimeout: 50 * time.Millisecond}
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	done := make(chan interface{})
+	go func() {
+		a.handleConnection(server)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("idle connection should be dropped after the first message timeout")
+	}
+}
+
+//remoteConn overrides the remote address of a piped connection
+type remoteConn struct {
+	net.Conn
+	remoteAddr net.Addr
+}
+
+func (c remoteConn) RemoteAddr() net.Addr { return c.remoteAddr }
+
+func TestAcceptorRefusesDisallowedRemoteBeforeCreatingSession(t *testing.T) {
+	cfg := `
+[DEFAULT]
+ConnectionType=acceptor
+SenderCompID=ISLD
+HeartBtInt=30
+
+[SESSION]
+BeginString=FIX.4.2
+TargetCompID=CLIENT[0-9]+
+AcceptorTemplate=Y
+AllowedRemoteAddresses=10.1.0.0/16
+`
+	settings, err := ParseSettings(strings.NewReader(cfg))
+	require.Nil(t, err)
+
+	a, err := NewAcceptor(new(MockApp), NewMemoryStoreFactory(), settings, nullLogFactory{})
+	require.Nil(t, err)
+	a.connections = newConnectionTracker(0)
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	go func() {
+		_, _ = client.Write(newBuffer("8=FIX.4.2|9=0|35=A|34=1|49=CLIENT1|52=20060102-15:04:05|56=ISLD|98=0|108=30|10=000|").Bytes())
+	}()
+
+	done := make(chan interface{})
+	go func() {
+		a.handleConnection(remoteConn{Conn: server, remoteAddr: &net.TCPAddr{IP: net.ParseIP("10.2.0.1"), Port: 5001}})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("connection from a disallowed remote should be closed")
+	}
+
+	sessionID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "CLIENT1"}
+	_, registered := lookupSession(sessionID)
+	assert.False(t, registered, "no session should be registered for a disallowed remote")
+	assert.Empty(t, a.DynamicSessions())
+}
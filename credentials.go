@@ -0,0 +1,152 @@
+package quickfix
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/quickfixgo/quickfix/config"
+)
+
+//credentialTags are the logon fields holding secrets. Logs mask these values.
+var credentialTags = []Tag{tagPassword, tagNewPassword}
+
+//Credentials are the Username(553), Password(554) and NewPassword(925) values sent in an initiator logon. Empty values are omitted.
+type Credentials struct {
+	Username    string
+	Password    string
+	NewPassword string
+}
+
+//CredentialsProvider supplies logon credentials for initiator sessions. Credentials are requested each time a logon is sent,
+//so providers may rotate passwords between logons.
+//
+//An Application that implements CredentialsProvider is consulted in preference to the LogonUsername, LogonPassword,
+//LogonNewPassword and LogonCredentialsFile settings.
+type CredentialsProvider interface {
+	Credentials(sessionID SessionID) (Credentials, error)
+}
+
+//CredentialsProviderFunc adapts a function to a CredentialsProvider.
+type CredentialsProviderFunc func(sessionID SessionID) (Credentials, error)
+
+//Credentials implements CredentialsProvider
+func (f CredentialsProviderFunc) Credentials(sessionID SessionID) (Credentials, error) {
+	return f(sessionID)
+}
+
+type staticCredentialsProvider struct {
+	credentials Credentials
+}
+
+//NewStaticCredentialsProvider returns a CredentialsProvider that always supplies the given credentials.
+func NewStaticCredentialsProvider(credentials Credentials) CredentialsProvider {
+	return staticCredentialsProvider{credentials}
+}
+
+func (p staticCredentialsProvider) Credentials(SessionID) (Credentials, error) {
+	return p.credentials, nil
+}
+
+type envCredentialsProvider struct {
+	usernameVar, passwordVar, newPasswordVar string
+}
+
+//NewEnvCredentialsProvider returns a CredentialsProvider that reads credentials from the named environment variables.
+//Variables are read at each logon. An empty variable name is skipped.
+func NewEnvCredentialsProvider(usernameVar, passwordVar, newPasswordVar string) CredentialsProvider {
+	return envCredentialsProvider{usernameVar: usernameVar, passwordVar: passwordVar, newPasswordVar: newPasswordVar}
+}
+
+func (p envCredentialsProvider) Credentials(SessionID) (credentials Credentials, err error) {
+	lookup := func(name string) string {
+		if name == "" {
+			return ""
+		}
+		return os.Getenv(name)
+	}
+
+	credentials.Username = lookup(p.usernameVar)
+	credentials.Password = lookup(p.passwordVar)
+	credentials.NewPassword = lookup(p.newPasswordVar)
+	return
+}
+
+type fileCredentialsProvider struct {
+	path string
+}
+
+//NewFileCredentialsProvider returns a CredentialsProvider that reads credentials from a file at each logon. The file
+//contains LogonUsername, LogonPassword and LogonNewPassword settings, one per line, e.g.
+//
+//  LogonUsername=trader1
+//  LogonPassword=secret
+//
+//Blank lines and lines beginning with # are ignored.
+func NewFileCredentialsProvider(path string) CredentialsProvider {
+	return fileCredentialsProvider{path}
+}
+
+func (p fileCredentialsProvider) Credentials(SessionID) (credentials Credentials, err error) {
+	file, err := os.Open(p.path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			return credentials, fmt.Errorf("error parsing line %v of %v", lineNumber, p.path)
+		}
+
+		switch key := strings.TrimSpace(parts[0]); key {
+		case config.LogonUsername:
+			credentials.Username = parts[1]
+		case config.LogonPassword:
+			credentials.Password = parts[1]
+		case config.LogonNewPassword:
+			credentials.NewPassword = parts[1]
+		default:
+			return credentials, fmt.Errorf("unknown setting %v in %v", key, p.path)
+		}
+	}
+
+	err = scanner.Err()
+	return
+}
+
+//setCredentials populates the logon with credentials from the session CredentialsProvider, if any.
+func (s *session) setCredentials(logon *Message) error {
+	if s.credentials == nil {
+		return nil
+	}
+
+	credentials, err := s.credentials.Credentials(s.sessionID)
+	if err != nil {
+		return err
+	}
+
+	if credentials.Username != "" {
+		logon.Body.SetField(tagUsername, FIXString(credentials.Username))
+	}
+
+	if credentials.Password != "" {
+		logon.Body.SetField(tagPassword, FIXString(credentials.Password))
+	}
+
+	if credentials.NewPassword != "" {
+		logon.Body.SetField(tagNewPassword, FIXString(credentials.NewPassword))
+	}
+
+	return nil
+}
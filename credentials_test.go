@@ -0,0 +1,65 @@
+package quickfix
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticCredentialsProvider(t *testing.T) {
+	expected := Credentials{Username: "user", Password: "pass"}
+	credentials, err := NewStaticCredentialsProvider(expected).Credentials(SessionID{})
+	assert.Nil(t, err)
+	assert.Equal(t, expected, credentials)
+}
+
+func TestEnvCredentialsProvider(t *testing.T) {
+	os.Setenv("QFTEST_LOGON_USERNAME", "user")
+	os.Setenv("QFTEST_LOGON_PASSWORD", "pass")
+	defer os.Unsetenv("QFTEST_LOGON_USERNAME")
+	defer os.Unsetenv("QFTEST_LOGON_PASSWORD")
+
+	credentials, err := NewEnvCredentialsProvider("QFTEST_LOGON_USERNAME", "QFTEST_LOGON_PASSWORD", "").Credentials(SessionID{})
+	assert.Nil(t, err)
+	assert.Equal(t, Credentials{Username: "user", Password: "pass"}, credentials)
+}
+
+func TestFileCredentialsProvider(t *testing.T) {
+	dir, err := ioutil.TempDir("", "credentials")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	credentialsFile := path.Join(dir, "credentials")
+	provider := NewFileCredentialsProvider(credentialsFile)
+
+	_, err = provider.Credentials(SessionID{})
+	assert.NotNil(t, err, "missing file should be an error")
+
+	require.Nil(t, ioutil.WriteFile(credentialsFile, []byte("# rotated weekly\nLogonUsername=user\nLogonPassword=pa=ss\n\nLogonNewPassword=next\n"), 0600))
+	credentials, err := provider.Credentials(SessionID{})
+	assert.Nil(t, err)
+	assert.Equal(t, Credentials{Username: "user", Password: "pa=ss", NewPassword: "next"}, credentials)
+
+	require.Nil(t, ioutil.WriteFile(credentialsFile, []byte("Password=secret\n"), 0600))
+	_, err = provider.Credentials(SessionID{})
+	assert.NotNil(t, err, "unknown settings should be an error")
+
+	require.Nil(t, ioutil.WriteFile(credentialsFile, []byte("LogonPassword\n"), 0600))
+	_, err = provider.Credentials(SessionID{})
+	assert.NotNil(t, err, "malformed lines should be an error")
+}
+
+func TestCredentialsProviderFunc(t *testing.T) {
+	sessionID := SessionID{BeginString: BeginStringFIX44, SenderCompID: "ISLD", TargetCompID: "TW"}
+	provider := CredentialsProviderFunc(func(id SessionID) (Credentials, error) {
+		return Credentials{Username: id.SenderCompID}, nil
+	})
+
+	credentials, err := provider.Credentials(sessionID)
+	assert.Nil(t, err)
+	assert.Equal(t, "ISLD", credentials.Username)
+}
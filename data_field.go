@@ -0,0 +1,180 @@
+package quickfix
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+//standardDataFields maps the standard data fields to the length fields declaring their size, used when no
+//DataDictionary is configured
+var standardDataFields = map[Tag]Tag{
+	89:   93,   //Signature, SignatureLength
+	91:   90,   //SecureData, SecureDataLen
+	96:   95,   //RawData, RawDataLength
+	213:  212,  //XmlData, XmlDataLen
+	349:  348,  //EncodedIssuer, EncodedIssuerLen
+	351:  350,  //EncodedSecurityDesc, EncodedSecurityDescLen
+	353:  352,  //EncodedListExecInst, EncodedListExecInstLen
+	355:  354,  //EncodedText, EncodedTextLen
+	357:  356,  //EncodedSubject, EncodedSubjectLen
+	359:  358,  //EncodedHeadline, EncodedHeadlineLen
+	361:  360,  //EncodedAllocText, EncodedAllocTextLen
+	363:  362,  //EncodedUnderlyingIssuer, EncodedUnderlyingIssuerLen
+	365:  364,  //EncodedUnderlyingSecurityDesc, EncodedUnderlyingSecurityDescLen
+	446:  445,  //EncodedListStatusText, EncodedListStatusTextLen
+	619:  618,  //EncodedLegIssuer, EncodedLegIssuerLen
+	622:  621,  //EncodedLegSecurityDesc, EncodedLegSecurityDescLen
+	1185: 1184, //SecurityXML, SecurityXMLLen
+	1402: 1401, //EncryptedPassword, EncryptedPasswordLen
+	1404: 1403, //EncryptedNewPassword, EncryptedNewPasswordLen
+}
+
+var standardLengthFields = func() map[Tag]Tag {
+	lengthFields := make(map[Tag]Tag, len(standardDataFields))
+	for dataTag, lengthTag := range standardDataFields {
+		lengthFields[lengthTag] = dataTag
+	}
+	return lengthFields
+}()
+
+//dataDictionaries are the dictionaries consulted for the types of fields while parsing a message
+type dataDictionaries [2]*datadictionary.DataDictionary
+
+//newDataDictionaries fills in a missing transport or application DataDictionary with the other
+func newDataDictionaries(transportDataDictionary, applicationDataDictionary *datadictionary.DataDictionary) dataDictionaries {
+	if transportDataDictionary == nil {
+		transportDataDictionary = applicationDataDictionary
+	} else if applicationDataDictionary == nil {
+		applicationDataDictionary = transportDataDictionary
+	}
+
+	return dataDictionaries{transportDataDictionary, applicationDataDictionary}
+}
+
+func (d dataDictionaries) hasFieldType(tag Tag, fixTypes ...string) bool {
+	for _, dict := range d {
+		if dict == nil {
+			continue
+		}
+
+		fieldType, ok := dict.FieldTypeByTag[int(tag)]
+		if !ok {
+			continue
+		}
+
+		for _, fixType := range fixTypes {
+			if fieldType.Type == fixType {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+//isLengthField returns true if tag may declare the length of the data field following it
+func (d dataDictionaries) isLengthField(tag Tag) bool {
+	if _, ok := standardLengthFields[tag]; ok {
+		return true
+	}
+
+	return tag != tagBodyLength && d.hasFieldType(tag, "LENGTH")
+}
+
+//isDataFieldOf returns true if tag is a data field whose length is declared by the preceding field lengthTag
+func (d dataDictionaries) isDataFieldOf(tag, lengthTag Tag) bool {
+	if standardLengthTag, ok := standardDataFields[tag]; ok {
+		return standardLengthTag == lengthTag
+	}
+
+	return d.hasFieldType(tag, "DATA", "XMLDATA") && d.hasFieldType(lengthTag, "LENGTH")
+}
+
+//fieldDef returns the definition of tag in the header, trailer or body of messages of type msgType
+func (d dataDictionaries) fieldDef(tag Tag, msgType []byte) (*datadictionary.FieldDef, bool) {
+	transportDict, appDict := d[0], d[1]
+	if transportDict == nil {
+		transportDict = appDict
+	} else if appDict == nil {
+		appDict = transportDict
+	}
+
+	if transportDict == nil {
+		return nil, false
+	}
+
+	var messageDef *datadictionary.MessageDef
+	switch {
+	case isHeaderField(tag, d[0]):
+		messageDef = transportDict.Header
+	case isTrailerField(tag, d[0]):
+		messageDef = transportDict.Trailer
+	default:
+		messageDef = appDict.Messages[string(msgType)]
+	}
+
+	if messageDef == nil {
+		return nil, false
+	}
+
+	fieldDef := messageDef.Fields[int(tag)]
+	return fieldDef, fieldDef != nil
+}
+
+//peekTag returns the tag of the next field in buffer
+func peekTag(buffer []byte) (Tag, bool) {
+	sepIndex := bytes.IndexByte(buffer, '=')
+	if sepIndex < 1 {
+		return 0, false
+	}
+
+	tag, err := atoi(buffer[:sepIndex])
+	if err != nil {
+		return 0, false
+	}
+
+	return Tag(tag), true
+}
+
+//dataFieldLength returns the declared length of the field at the start of buffer if it is a data field following
+//lengthField
+func dataFieldLength(lengthField *TagValue, buffer []byte, dicts dataDictionaries) (int, bool) {
+	if lengthField == nil {
+		return 0, false
+	}
+
+	tag, ok := peekTag(buffer)
+	if !ok || !dicts.isDataFieldOf(tag, lengthField.tag) {
+		return 0, false
+	}
+
+	length, err := atoi(lengthField.value)
+	if err != nil || length < 0 {
+		return 0, false
+	}
+
+	return length, true
+}
+
+//extractDataField extracts a data field of exactly length bytes, which may contain SOH
+func extractDataField(parsedFieldBytes *TagValue, buffer []byte, length int) (remBytes []byte, err error) {
+	sepIndex := bytes.IndexByte(buffer, '=')
+	endIndex := sepIndex + 1 + length
+	if sepIndex == -1 || endIndex >= len(buffer) || buffer[endIndex] != '\001' {
+		err = parseError{OrigError: fmt.Sprintf("extractDataField: No Trailing Delim after %d bytes of data in %s", length, string(buffer))}
+		remBytes = buffer
+		return
+	}
+
+	err = parsedFieldBytes.parse(buffer[:endIndex+1])
+	return buffer[(endIndex + 1):], err
+}
+
+//SetData is a SetField wrapper for a data field and the length field declaring its size, e.g. RawDataLength(95) and
+//RawData(96). The value may contain SOH.
+func (m *FieldMap) SetData(lengthTag, dataTag Tag, value []byte) *FieldMap {
+	m.SetInt(lengthTag, len(value))
+	return m.SetBytes(dataTag, value)
+}
@@ -0,0 +1,70 @@
+package quickfix
+
+import (
+	"bytes"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+func (s *MessageSuite) buildWithData(lengthTag, dataTag Tag, data []byte) *bytes.Buffer {
+	msg := NewMessage()
+	msg.Header.SetField(tagBeginString, FIXString(BeginStringFIX44))
+	msg.Header.SetField(tagMsgType, FIXString("B"))
+	msg.Header.SetField(tagSendingTime, FIXString("20140615-19:49:56"))
+	msg.Body.SetField(Tag(148), FIXString("headline"))
+	msg.Body.SetData(lengthTag, dataTag, data)
+	msg.Body.SetField(Tag(10000), FIXString("after"))
+
+	return bytes.NewBuffer(msg.build())
+}
+
+func (s *MessageSuite) TestParseDataFieldContainingSOH() {
+	data := []byte("a\001b=c\001\001")
+	for _, test := range []struct{ lengthTag, dataTag Tag }{
+		{95, 96},
+		{354, 355},
+	} {
+		rawMsg := s.buildWithData(test.lengthTag, test.dataTag, data)
+
+		s.Require().Nil(ParseMessage(s.msg, rawMsg))
+		s.FieldEquals(test.lengthTag, len(data), s.msg.Body)
+		s.FieldEquals(test.dataTag, string(data), s.msg.Body)
+		s.FieldEquals(Tag(10000), "after", s.msg.Body)
+		s.Equal(string(rawMsg.Bytes()), string(s.msg.build()))
+	}
+}
+
+func (s *MessageSuite) TestParseDataFieldWithDataDictionary() {
+	dict := new(datadictionary.DataDictionary)
+	dict.Header = &datadictionary.MessageDef{Fields: map[int]*datadictionary.FieldDef{}}
+	dict.Trailer = &datadictionary.MessageDef{Fields: map[int]*datadictionary.FieldDef{}}
+	dict.FieldTypeByTag = map[int]*datadictionary.FieldType{
+		5000: datadictionary.NewFieldType("CustomDataLen", 5000, "LENGTH"),
+		5001: datadictionary.NewFieldType("CustomData", 5001, "DATA"),
+	}
+
+	data := []byte("x\001y")
+	rawMsg := s.buildWithData(5000, 5001, data)
+	s.NotNil(ParseMessage(s.msg, bytes.NewBuffer(rawMsg.Bytes())), "without dictionary the data is split on SOH")
+
+	s.Require().Nil(ParseMessageWithDataDictionary(s.msg, rawMsg, dict, dict))
+	s.FieldEquals(Tag(5001), string(data), s.msg.Body)
+	s.FieldEquals(Tag(10000), "after", s.msg.Body)
+}
+
+func (s *MessageSuite) TestParseDataFieldLengthMismatch() {
+	rawMsg := bytes.NewBufferString("8=FIX.4.49=3735=B52=20140615-19:49:5695=496=ab10=000")
+	s.NotNil(ParseMessage(s.msg, rawMsg))
+}
+
+func (s *MessageSuite) TestBuildSignature() {
+	s.msg.Header.SetField(tagBeginString, FIXString(BeginStringFIX44))
+	s.msg.Header.SetField(tagMsgType, FIXString("0"))
+	s.msg.Trailer.SetData(tagSignatureLength, tagSignature, []byte("s\001g"))
+
+	rawMsg := s.msg.build()
+	s.Contains(string(rawMsg), "93=3\00189=s\001g\00110=")
+
+	s.Require().Nil(ParseMessage(s.msg, bytes.NewBuffer(rawMsg)))
+	s.FieldEquals(tagSignature, "s\001g", s.msg.Trailer)
+}
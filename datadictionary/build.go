@@ -111,7 +111,17 @@ func (b builder) buildComponentType(xmlComponent *XMLComponent) (*ComponentType,
 		}
 	}
 
-	return NewComponentType(xmlComponent.Name, parts), nil
+	comp := NewComponentType(xmlComponent.Name, parts)
+	comp.abbreviation = xmlComponent.Abbr
+
+	//as in FIXML, the instances of a group wrapped by a component are named after the component
+	if len(parts) == 1 {
+		if group, ok := parts[0].(*FieldDef); ok && group.IsGroup() && group.abbreviation == "" {
+			group.abbreviation = comp.Abbreviation()
+		}
+	}
+
+	return comp, nil
 }
 
 func (b builder) buildComponents() error {
@@ -165,7 +175,10 @@ func (b builder) buildMessageDef(xmlMessage *XMLComponent) (*MessageDef, error)
 		}
 	}
 
-	return NewMessageDef(xmlMessage.Name, xmlMessage.MsgType, parts), nil
+	msg := NewMessageDef(xmlMessage.Name, xmlMessage.MsgType, parts)
+	msg.abbreviation = xmlMessage.Abbr
+
+	return msg, nil
 }
 
 func (b builder) buildGroupFieldDef(xmlField *XMLComponentMember, groupFieldType *FieldType) (*FieldDef, error) {
@@ -195,7 +208,10 @@ func (b builder) buildGroupFieldDef(xmlField *XMLComponentMember, groupFieldType
 		}
 	}
 
-	return NewGroupFieldDef(groupFieldType, xmlField.isRequired(), parts), nil
+	group := NewGroupFieldDef(groupFieldType, xmlField.isRequired(), parts)
+	group.abbreviation = xmlField.Abbr
+
+	return group, nil
 }
 
 func (b builder) buildFieldDef(xmlField *XMLComponentMember) (*FieldDef, error) {
@@ -211,7 +227,10 @@ func (b builder) buildFieldDef(xmlField *XMLComponentMember) (*FieldDef, error)
 		return f, err
 	}
 
-	return NewFieldDef(fieldType, xmlField.isRequired()), nil
+	field := NewFieldDef(fieldType, xmlField.isRequired())
+	field.abbreviation = xmlField.Abbr
+
+	return field, nil
 }
 
 func (b builder) buildFieldTypes() {
@@ -226,6 +245,7 @@ func (b builder) buildFieldTypes() {
 
 func buildFieldType(xmlField *XMLField) *FieldType {
 	field := NewFieldType(xmlField.Name, xmlField.Number, xmlField.Type)
+	field.abbreviation = xmlField.Abbr
 
 	if len(xmlField.Values) > 0 {
 		field.Enums = make(map[string]Enum)
@@ -4,6 +4,7 @@ package datadictionary
 import (
 	"encoding/xml"
 	"os"
+	"sync/atomic"
 )
 
 //DataDictionary models FIX messages, components, and fields.
@@ -36,6 +37,7 @@ type messagePartWithFields interface {
 //ComponentType is a grouping of fields.
 type ComponentType struct {
 	name           string
+	abbreviation   string
 	parts          []MessagePart
 	fields         []*FieldDef
 	requiredFields []*FieldDef
@@ -77,6 +79,15 @@ func NewComponentType(name string, parts []MessagePart) *ComponentType {
 //Name returns the name of this component type
 func (c ComponentType) Name() string { return c.name }
 
+//Abbreviation returns the FIXML element name of this component type, the name if no abbreviation is defined
+func (c ComponentType) Abbreviation() string {
+	if c.abbreviation != "" {
+		return c.abbreviation
+	}
+
+	return c.name
+}
+
 //Fields returns all fields contained in this component. Includes fields
 //encapsulated in components of this component
 func (c ComponentType) Fields() []*FieldDef { return c.fields }
@@ -130,6 +141,10 @@ type FieldDef struct {
 	Fields         []*FieldDef
 	requiredParts  []MessagePart
 	requiredFields []*FieldDef
+
+	abbreviation string
+
+	derived atomic.Value
 }
 
 //NewFieldDef returns an initialized FieldDef
@@ -175,10 +190,33 @@ func NewGroupFieldDef(fieldType *FieldType, required bool, parts []MessagePart)
 	return &field
 }
 
+//Derived returns the value build derives from the FieldDef. build is only called until a value is held, so values such as
+//the parsing template of a repeating group live as long as the DataDictionary rather than in a global cache. build must
+//always return the same concrete type.
+func (f *FieldDef) Derived(build func() interface{}) interface{} {
+	if value := f.derived.Load(); value != nil {
+		return value
+	}
+
+	value := build()
+	f.derived.Store(value)
+	return value
+}
+
 //Required returns true if this FieldDef is required for the containing
 //MessageDef
 func (f FieldDef) Required() bool { return f.required }
 
+//Abbreviation returns the FIXML name of this field, the element name of each instance of a repeating group. Defaults
+//to the abbreviation of the component wrapping nothing but the group, then to the abbreviation of the FieldType.
+func (f FieldDef) Abbreviation() string {
+	if f.abbreviation != "" {
+		return f.abbreviation
+	}
+
+	return f.FieldType.Abbreviation()
+}
+
 //IsGroup is true if the field is a repeating group.
 func (f FieldDef) IsGroup() bool {
 	return len(f.Fields) > 0
@@ -228,6 +266,8 @@ type FieldType struct {
 	tag   int
 	Type  string
 	Enums map[string]Enum
+
+	abbreviation string
 }
 
 //NewFieldType returns a pointer to an initialized FieldType
@@ -245,6 +285,15 @@ func (f FieldType) Name() string { return f.name }
 //Tag returns the tag for this fieldType
 func (f FieldType) Tag() int { return f.tag }
 
+//Abbreviation returns the FIXML attribute name of this field type, the name if no abbreviation is defined
+func (f FieldType) Abbreviation() string {
+	if f.abbreviation != "" {
+		return f.abbreviation
+	}
+
+	return f.name
+}
+
 //Enum is a container for value and description.
 type Enum struct {
 	Value       string
@@ -263,11 +312,22 @@ type MessageDef struct {
 
 	RequiredTags TagSet
 	Tags         TagSet
+
+	abbreviation string
 }
 
 //RequiredParts returns those parts that are required for this Message
 func (m MessageDef) RequiredParts() []MessagePart { return m.requiredParts }
 
+//Abbreviation returns the FIXML element name of this MessageDef, the name if no abbreviation is defined
+func (m MessageDef) Abbreviation() string {
+	if m.abbreviation != "" {
+		return m.abbreviation
+	}
+
+	return m.Name
+}
+
 //NewMessageDef returns a pointer to an initialized MessageDef
 func NewMessageDef(name, msgType string, parts []MessagePart) *MessageDef {
 	msg := MessageDef{
@@ -189,3 +189,43 @@ func TestMessageTags(t *testing.T) {
 		}
 	}
 }
+
+func TestAbbreviations(t *testing.T) {
+	d, err := Parse("../_test_data/fixml/FIX44.xml")
+	if err != nil {
+		t.Fatalf("Unexpected err: %v", err)
+	}
+
+	var tests = []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{"header", d.Header.Abbreviation(), "Hdr"},
+		{"message", d.Messages["D"].Abbreviation(), "Order"},
+		{"field type", d.FieldTypeByName["ClOrdID"].Abbreviation(), "ID"},
+		{"field", d.Messages["D"].Fields[11].Abbreviation(), "ID"},
+		{"component", d.ComponentTypes["Instrument"].Abbreviation(), "Instrmt"},
+		{"group", d.Messages["V"].Fields[267].Abbreviation(), "Req"},
+		{"group wrapped by component", d.ComponentTypes["Parties"].Fields()[0].Abbreviation(), "Pty"},
+	}
+
+	d, _ = dict()
+	tests = append(tests, []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{"message without abbreviation", d.Messages["D"].Abbreviation(), "NewOrderSingle"},
+		{"field type without abbreviation", d.FieldTypeByName["ClOrdID"].Abbreviation(), "ClOrdID"},
+		{"field without abbreviation", d.Messages["D"].Fields[11].Abbreviation(), "ClOrdID"},
+		{"component without abbreviation", d.ComponentTypes["Instrument"].Abbreviation(), "Instrument"},
+		{"group without abbreviation", d.Messages["V"].Fields[267].Abbreviation(), "NoMDEntryTypes"},
+	}...)
+
+	for _, test := range tests {
+		if test.actual != test.expected {
+			t.Errorf("Expected %v abbreviation %v got %v", test.name, test.expected, test.actual)
+		}
+	}
+}
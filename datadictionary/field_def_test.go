@@ -23,3 +23,20 @@ func TestNewFieldDef(t *testing.T) {
 		assert.Equal(t, test.required, fd.Required())
 	}
 }
+
+func TestFieldDefDerived(t *testing.T) {
+	fd := datadictionary.NewFieldDef(datadictionary.NewFieldType("aname", 11, "INT"), false)
+
+	builds := 0
+	build := func() interface{} {
+		builds++
+		return builds
+	}
+
+	assert.Equal(t, 1, fd.Derived(build))
+	assert.Equal(t, 1, fd.Derived(build))
+	assert.Equal(t, 1, builds)
+
+	other := datadictionary.NewFieldDef(datadictionary.NewFieldType("aname", 11, "INT"), false)
+	assert.Equal(t, 2, other.Derived(build))
+}
@@ -23,6 +23,7 @@ type XMLComponent struct {
 	Name    string `xml:"name,attr"`
 	MsgCat  string `xml:"msgcat,attr"`
 	MsgType string `xml:"msgtype,attr"`
+	Abbr    string `xml:"abbr,attr"`
 
 	Members []*XMLComponentMember `xml:",any"`
 }
@@ -32,6 +33,7 @@ type XMLField struct {
 	Number int         `xml:"number,attr"`
 	Name   string      `xml:"name,attr"`
 	Type   string      `xml:"type,attr"`
+	Abbr   string      `xml:"abbr,attr"`
 	Values []*XMLValue `xml:"value"`
 }
 
@@ -46,6 +48,7 @@ type XMLComponentMember struct {
 	XMLName  xml.Name
 	Name     string `xml:"name,attr"`
 	Required string `xml:"required,attr"`
+	Abbr     string `xml:"abbr,attr"`
 
 	Members []*XMLComponentMember `xml:",any"`
 }
@@ -0,0 +1,207 @@
+package quickfix
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/quickfixgo/quickfix/config"
+)
+
+//DynamicSessionPolicy controls the lifecycle of sessions an Acceptor creates on the fly, either with DynamicSessions
+//enabled or from acceptor templates.
+type DynamicSessionPolicy struct {
+	//Approve, if set, is called before a dynamic session is created. Returning false refuses the connection.
+	Approve func(sessionID SessionID, remoteAddr net.Addr) bool
+
+	//MaxSessions is the maximum number of dynamic sessions that may exist at once. 0 means no limit.
+	MaxSessions int
+
+	//Linger is how long a dynamic session remains registered after its connection is closed. A counterparty
+	//reconnecting within this time resumes the same session, and messages may still be sent with SendToTarget.
+	//0 unregisters the session as soon as the connection closes.
+	Linger time.Duration
+}
+
+//dynamicSession is a session created by the acceptor for an incoming logon.
+type dynamicSession struct {
+	*session
+	connections int
+	generation  int
+	linger      Timer
+	stopping    bool
+}
+
+//SetDynamicSessionPolicy replaces the policy for dynamic sessions, including any MaxDynamicSessions and
+//DynamicSessionLinger settings. Must be called before Start.
+func (a *Acceptor) SetDynamicSessionPolicy(policy DynamicSessionPolicy) {
+	a.dynamicSessionPolicy = policy
+}
+
+//DynamicSessions returns the IDs of the dynamic sessions currently registered with the acceptor, including sessions
+//lingering after a disconnect.
+func (a *Acceptor) DynamicSessions() []SessionID {
+	a.dynamicSessionsLock.Lock()
+	defer a.dynamicSessionsLock.Unlock()
+
+	sessionIDs := make([]SessionID, 0, len(a.activeDynamicSessions))
+	for sessionID, ds := range a.activeDynamicSessions {
+		if !ds.stopping {
+			sessionIDs = append(sessionIDs, sessionID)
+		}
+	}
+
+	sort.Slice(sessionIDs, func(i, j int) bool { return sessionIDs[i].String() < sessionIDs[j].String() })
+	return sessionIDs
+}
+
+//parseDynamicSessionPolicy reads the dynamic session policy from the global settings.
+func parseDynamicSessionPolicy(settings *SessionSettings) (policy DynamicSessionPolicy, err error) {
+	if settings.HasSetting(config.MaxDynamicSessions) {
+		if policy.MaxSessions, err = settings.IntSetting(config.MaxDynamicSessions); err != nil {
+			return
+		}
+
+		if policy.MaxSessions <= 0 {
+			err = fmt.Errorf("MaxDynamicSessions must be greater than zero")
+			return
+		}
+	}
+
+	if settings.HasSetting(config.DynamicSessionLinger) {
+		policy.Linger, err = settings.DurationSetting(config.DynamicSessionLinger)
+	}
+
+	return
+}
+
+//acquireDynamicSession returns the dynamic session for sessionID, creating it with settings if needed. Each successful call must
+//be paired with a call to releaseDynamicSession once the connection closes.
+func (a *Acceptor) acquireDynamicSession(sessionID SessionID, settings *SessionSettings, remoteAddr net.Addr) (*dynamicSession, error) {
+	if ds, ok, err := a.resumeDynamicSession(sessionID); ok || err != nil {
+		return ds, err
+	}
+
+	if a.dynamicSessionPolicy.Approve != nil && !a.dynamicSessionPolicy.Approve(sessionID, remoteAddr) {
+		return nil, fmt.Errorf("dynamic session %v not approved", sessionID)
+	}
+
+	a.dynamicSessionsLock.Lock()
+	if ds, ok := a.activeDynamicSessions[sessionID]; ok {
+		a.dynamicSessionsLock.Unlock()
+		if ds.stopping {
+			return nil, fmt.Errorf("dynamic session %v is stopping", sessionID)
+		}
+		return a.acquireDynamicSession(sessionID, settings, remoteAddr)
+	}
+
+	if max := a.dynamicSessionPolicy.MaxSessions; max > 0 && len(a.activeDynamicSessions) >= max {
+		a.dynamicSessionsLock.Unlock()
+		return nil, fmt.Errorf("maximum dynamic sessions reached, refusing %v", sessionID)
+	}
+
+	s, err := a.sessionFactory.createSession(sessionID, a.storeFactory, settings, a.logFactory, a.app)
+	if err != nil {
+		a.dynamicSessionsLock.Unlock()
+		return nil, fmt.Errorf("dynamic session %v failed to create: %v", sessionID, err)
+	}
+
+	ds := &dynamicSession{session: s, connections: 1}
+	a.activeDynamicSessions[sessionID] = ds
+	a.dynamicSessionsLock.Unlock()
+
+	a.dynamicSessionChan <- ds
+	return ds, nil
+}
+
+//resumeDynamicSession returns the registered dynamic session for sessionID, if any, cancelling its linger timer.
+func (a *Acceptor) resumeDynamicSession(sessionID SessionID) (*dynamicSession, bool, error) {
+	a.dynamicSessionsLock.Lock()
+	defer a.dynamicSessionsLock.Unlock()
+
+	ds, ok := a.activeDynamicSessions[sessionID]
+	if !ok {
+		return nil, false, nil
+	}
+
+	if ds.stopping {
+		return nil, false, fmt.Errorf("dynamic session %v is stopping", sessionID)
+	}
+
+	ds.connections++
+	if ds.linger != nil {
+		ds.linger.Stop()
+		ds.linger = nil
+	}
+
+	return ds, true, nil
+}
+
+//releaseDynamicSession is called when a connection to a dynamic session closes. The session is stopped once it has no
+//connections, after the policy linger time.
+func (a *Acceptor) releaseDynamicSession(ds *dynamicSession) {
+	a.dynamicSessionsLock.Lock()
+
+	ds.connections--
+	if ds.connections > 0 || ds.stopping {
+		a.dynamicSessionsLock.Unlock()
+		return
+	}
+
+	if a.dynamicSessionPolicy.Linger <= 0 {
+		ds.stopping = true
+		a.dynamicSessionsLock.Unlock()
+		ds.stop()
+		return
+	}
+
+	ds.generation++
+	generation := ds.generation
+	ds.linger = a.sessionClock().AfterFunc(a.dynamicSessionPolicy.Linger, func() { a.expireDynamicSession(ds, generation) })
+	a.dynamicSessionsLock.Unlock()
+}
+
+//expireDynamicSession stops a dynamic session whose linger time has elapsed without a reconnect.
+func (a *Acceptor) expireDynamicSession(ds *dynamicSession, generation int) {
+	a.dynamicSessionsLock.Lock()
+	if ds.generation != generation || ds.connections > 0 || ds.stopping {
+		a.dynamicSessionsLock.Unlock()
+		return
+	}
+
+	ds.stopping = true
+	ds.linger = nil
+	a.dynamicSessionsLock.Unlock()
+
+	a.globalLog.OnEventf("Dynamic session %v expired", ds.sessionID)
+	ds.stop()
+}
+
+//stopDynamicSession stops ds unless it is already stopping.
+func (a *Acceptor) stopDynamicSession(ds *dynamicSession) {
+	a.dynamicSessionsLock.Lock()
+	if ds.stopping {
+		a.dynamicSessionsLock.Unlock()
+		return
+	}
+
+	ds.stopping = true
+	if ds.linger != nil {
+		ds.linger.Stop()
+		ds.linger = nil
+	}
+	a.dynamicSessionsLock.Unlock()
+
+	ds.stop()
+}
+
+//removeDynamicSession forgets ds once its session has stopped and been unregistered.
+func (a *Acceptor) removeDynamicSession(ds *dynamicSession) {
+	a.dynamicSessionsLock.Lock()
+	defer a.dynamicSessionsLock.Unlock()
+
+	if a.activeDynamicSessions[ds.sessionID] == ds {
+		delete(a.activeDynamicSessions, ds.sessionID)
+	}
+}
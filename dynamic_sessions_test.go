@@ -0,0 +1,126 @@
+package quickfix
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix/config"
+	"github.com/stretchr/testify/suite"
+)
+
+type DynamicSessionsSuite struct {
+	suite.Suite
+	acceptor *Acceptor
+	done     chan interface{}
+}
+
+func TestDynamicSessionsSuite(t *testing.T) {
+	suite.Run(t, new(DynamicSessionsSuite))
+}
+
+func (s *DynamicSessionsSuite) SetupTest() {
+	settings := NewSettings()
+	settings.GlobalSettings().Set(config.DynamicSessions, "Y")
+	settings.GlobalSettings().Set(config.MaxDynamicSessions, "1")
+	settings.GlobalSettings().Set(config.DynamicSessionLinger, "50ms")
+
+	var err error
+	s.acceptor, err = NewAcceptor(new(MockApp), NewMemoryStoreFactory(), settings, nullLogFactory{})
+	s.Require().Nil(err)
+	s.Equal(1, s.acceptor.dynamicSessionPolicy.MaxSessions)
+	s.Equal(50*time.Millisecond, s.acceptor.dynamicSessionPolicy.Linger)
+
+	s.acceptor.dynamicSessionChan = make(chan *dynamicSession)
+	s.done = make(chan interface{})
+	go func() {
+		s.acceptor.dynamicSessionsLoop()
+		close(s.done)
+	}()
+}
+
+func (s *DynamicSessionsSuite) TearDownTest() {
+	close(s.acceptor.dynamicSessionChan)
+	<-s.done
+}
+
+func (s *DynamicSessionsSuite) remoteAddr() net.Addr {
+	return &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}
+}
+
+func (s *DynamicSessionsSuite) acquire(sessionID SessionID) (*dynamicSession, error) {
+	settings, ok := s.acceptor.dynamicSessionSettings(sessionID)
+	s.Require().True(ok)
+	return s.acceptor.acquireDynamicSession(sessionID, settings, s.remoteAddr())
+}
+
+func (s *DynamicSessionsSuite) TestMaxSessions() {
+	sessionID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW"}
+	ds, err := s.acquire(sessionID)
+	s.Require().Nil(err)
+	s.Equal([]SessionID{sessionID}, s.acceptor.DynamicSessions())
+
+	_, err = s.acquire(SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "OTHER"})
+	s.NotNil(err, "should refuse sessions over the maximum")
+
+	s.acceptor.SetDynamicSessionPolicy(DynamicSessionPolicy{})
+	s.acceptor.releaseDynamicSession(ds)
+	s.Eventually(func() bool {
+		_, registered := lookupSession(sessionID)
+		return len(s.acceptor.DynamicSessions()) == 0 && !registered
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *DynamicSessionsSuite) TestLinger() {
+	sessionID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW"}
+	ds, err := s.acquire(sessionID)
+	s.Require().Nil(err)
+	s.acceptor.releaseDynamicSession(ds)
+
+	_, registered := lookupSession(sessionID)
+	s.True(registered, "session should remain registered while lingering")
+	s.Equal([]SessionID{sessionID}, s.acceptor.DynamicSessions())
+
+	resumed, err := s.acquire(sessionID)
+	s.Require().Nil(err)
+	s.True(ds == resumed, "reconnect within linger should resume the session")
+
+	time.Sleep(100 * time.Millisecond)
+	s.Equal([]SessionID{sessionID}, s.acceptor.DynamicSessions(), "connected session should not expire")
+
+	s.acceptor.releaseDynamicSession(resumed)
+	s.Eventually(func() bool {
+		_, registered := lookupSession(sessionID)
+		return len(s.acceptor.DynamicSessions()) == 0 && !registered
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *DynamicSessionsSuite) TestApprove() {
+	var approvedAddr net.Addr
+	s.acceptor.SetDynamicSessionPolicy(DynamicSessionPolicy{Approve: func(sessionID SessionID, remoteAddr net.Addr) bool {
+		approvedAddr = remoteAddr
+		return sessionID.TargetCompID == "TW"
+	}})
+
+	_, err := s.acquire(SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "OTHER"})
+	s.NotNil(err)
+	s.Equal(s.remoteAddr(), approvedAddr)
+	s.Empty(s.acceptor.DynamicSessions())
+
+	sessionID := SessionID{BeginString: BeginStringFIX42, SenderCompID: "ISLD", TargetCompID: "TW"}
+	ds, err := s.acquire(sessionID)
+	s.Require().Nil(err)
+	s.Equal([]SessionID{sessionID}, s.acceptor.DynamicSessions())
+
+	s.acceptor.releaseDynamicSession(ds)
+	s.Eventually(func() bool { return len(s.acceptor.DynamicSessions()) == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestParseDynamicSessionPolicyInvalid(t *testing.T) {
+	settings := NewSessionSettings()
+	settings.Set(config.MaxDynamicSessions, "0")
+	_, err := parseDynamicSessionPolicy(settings)
+	if err == nil {
+		t.Error("expected error for MaxDynamicSessions=0")
+	}
+}
@@ -1,8 +1,8 @@
 package quickfix
 
 import (
-	"bytes"
 	"sort"
+	"strconv"
 	"time"
 )
 
@@ -13,14 +13,11 @@ func fieldTag(f field) Tag {
 	return f[0].tag
 }
 
-func initField(f field, tag Tag, value []byte) {
-	f[0].init(tag, value)
-}
-
-func writeField(f field, buffer *bytes.Buffer) {
+func appendField(b []byte, f field) []byte {
 	for _, tv := range f {
-		buffer.Write(tv.bytes)
+		b = append(b, tv.bytes...)
 	}
+	return b
 }
 
 // tagOrder true if tag i should occur before tag j
@@ -39,6 +36,14 @@ func (t tagSort) Less(i, j int) bool { return t.compare(t.tags[i], t.tags[j]) }
 type FieldMap struct {
 	tagLookup map[Tag]field
 	tagSort
+
+	//groups are the repeating groups built while parsing with a DataDictionary, kept as spareGroups once cleared
+	//for reuse by the next parse
+	groups      map[Tag]*RepeatingGroup
+	spareGroups map[Tag]*RepeatingGroup
+
+	//storage holds the fields set on this FieldMap, reused once cleared
+	storage fieldStorage
 }
 
 // ascending tags
@@ -137,6 +142,14 @@ func (m FieldMap) GetTime(tag Tag) (t time.Time, err MessageRejectError) {
 	return val.Time, err
 }
 
+//GetTimeWithPrecision is a GetField wrapper for utc timestamp fields, also returning the precision the timestamp was
+//received with
+func (m FieldMap) GetTimeWithPrecision(tag Tag) (time.Time, TimestampPrecision, MessageRejectError) {
+	var val FIXUTCTimestamp
+	err := m.GetField(tag, &val)
+	return val.Time, val.Precision, err
+}
+
 //GetString is a GetField wrapper for string fields
 func (m FieldMap) GetString(tag Tag) (string, MessageRejectError) {
 	var val FIXString
@@ -146,13 +159,85 @@ func (m FieldMap) GetString(tag Tag) (string, MessageRejectError) {
 	return string(val), nil
 }
 
-//GetGroup is a Get function specific to Group Fields.
+//GetChar is a GetField wrapper for char fields
+func (m FieldMap) GetChar(tag Tag) (byte, MessageRejectError) {
+	var val FIXChar
+	if err := m.GetField(tag, &val); err != nil {
+		return 0, err
+	}
+	return byte(val), nil
+}
+
+//GetMultipleValueString is a GetField wrapper for multiple value string fields
+func (m FieldMap) GetMultipleValueString(tag Tag) ([]string, MessageRejectError) {
+	var val FIXMultipleValueString
+	if err := m.GetField(tag, &val); err != nil {
+		return nil, err
+	}
+	return []string(val), nil
+}
+
+//GetUTCDateOnly is a GetField wrapper for utc date only fields
+func (m FieldMap) GetUTCDateOnly(tag Tag) (time.Time, MessageRejectError) {
+	var val FIXUTCDateOnly
+	err := m.GetField(tag, &val)
+	return val.Time, err
+}
+
+//GetUTCTimeOnly is a GetField wrapper for utc time only fields
+func (m FieldMap) GetUTCTimeOnly(tag Tag) (time.Time, MessageRejectError) {
+	var val FIXUTCTimeOnly
+	err := m.GetField(tag, &val)
+	return val.Time, err
+}
+
+//GetLocalMktDate is a GetField wrapper for local market date fields
+func (m FieldMap) GetLocalMktDate(tag Tag) (time.Time, MessageRejectError) {
+	var val FIXLocalMktDate
+	err := m.GetField(tag, &val)
+	return val.Time, err
+}
+
+//GetTZTimestamp is a GetField wrapper for timestamp fields with a utc offset
+func (m FieldMap) GetTZTimestamp(tag Tag) (time.Time, MessageRejectError) {
+	var val FIXTZTimestamp
+	err := m.GetField(tag, &val)
+	return val.Time, err
+}
+
+//GetTZTimestampWithPrecision is a GetField wrapper for timestamp fields with a utc offset, also returning the precision
+//the timestamp was received with
+func (m FieldMap) GetTZTimestampWithPrecision(tag Tag) (time.Time, TimestampPrecision, MessageRejectError) {
+	var val FIXTZTimestamp
+	err := m.GetField(tag, &val)
+	return val.Time, val.Precision, err
+}
+
+//GetMonthYear is a GetField wrapper for month year fields
+func (m FieldMap) GetMonthYear(tag Tag) (FIXMonthYear, MessageRejectError) {
+	var val FIXMonthYear
+	err := m.GetField(tag, &val)
+	return val, err
+}
+
+//GetGroup is a Get function specific to Group Fields. A RepeatingGroup without a template is populated from the
+//group structure built when the message was parsed with a DataDictionary, valid until the message is parsed again.
 func (m FieldMap) GetGroup(parser FieldGroupReader) MessageRejectError {
 	f, ok := m.tagLookup[parser.Tag()]
 	if !ok {
 		return ConditionallyRequiredFieldMissing(parser.Tag())
 	}
 
+	if rg, ok := parser.(*RepeatingGroup); ok && len(rg.template) == 0 {
+		if parsed, ok := m.groups[parser.Tag()]; ok {
+			rg.template = parsed.template
+			rg.ordering = parsed.ordering
+			rg.groups = append([]*Group(nil), parsed.groups...)
+			rg.borrowed = true
+			return nil
+		}
+	}
+
 	if _, err := parser.Read(f); err != nil {
 		if msgRejErr, ok := err.(MessageRejectError); ok {
 			return msgRejErr
@@ -171,7 +256,7 @@ func (m *FieldMap) SetField(tag Tag, field FieldValueWriter) *FieldMap {
 //SetBytes sets bytes
 func (m *FieldMap) SetBytes(tag Tag, value []byte) *FieldMap {
 	f := m.getOrCreate(tag)
-	initField(f, tag, value)
+	m.storage.initTagValue(&f[0], tag, value)
 	return m
 }
 
@@ -182,8 +267,8 @@ func (m *FieldMap) SetBool(tag Tag, value bool) *FieldMap {
 
 //SetInt is a SetField wrapper for int fields
 func (m *FieldMap) SetInt(tag Tag, value int) *FieldMap {
-	v := FIXInt(value)
-	return m.SetBytes(tag, v.Write())
+	var b [20]byte
+	return m.SetBytes(tag, strconv.AppendInt(b[:0], int64(value), 10))
 }
 
 //SetString is a SetField wrapper for string fields
@@ -191,25 +276,54 @@ func (m *FieldMap) SetString(tag Tag, value string) *FieldMap {
 	return m.SetBytes(tag, []byte(value))
 }
 
+//SetChar is a SetField wrapper for char fields
+func (m *FieldMap) SetChar(tag Tag, value byte) *FieldMap {
+	return m.SetField(tag, FIXChar(value))
+}
+
+//SetMultipleValueString is a SetField wrapper for multiple value string fields
+func (m *FieldMap) SetMultipleValueString(tag Tag, values ...string) *FieldMap {
+	return m.SetField(tag, FIXMultipleValueString(values))
+}
+
 //Clear purges all fields from field map
 func (m *FieldMap) Clear() {
 	m.tags = m.tags[0:0]
 	for k := range m.tagLookup {
 		delete(m.tagLookup, k)
 	}
+	for k, rg := range m.groups {
+		if m.spareGroups == nil {
+			m.spareGroups = make(map[Tag]*RepeatingGroup)
+		}
+		m.spareGroups[k] = rg
+		delete(m.groups, k)
+	}
+	m.storage.reset()
 }
 
 //CopyInto overwrites the given FieldMap with this one
 func (m *FieldMap) CopyInto(to *FieldMap) {
 	to.tagLookup = make(map[Tag]field)
+	to.storage.reset()
 	for tag, f := range m.tagLookup {
-		clone := make(field, 1)
-		clone[0] = f[0]
+		clone := to.storage.newField(len(f))
+		for i := range f {
+			to.storage.initTagValue(&clone[i], f[i].tag, f[i].value)
+		}
 		to.tagLookup[tag] = clone
 	}
 	to.tags = make([]Tag, len(m.tags))
 	copy(to.tags, m.tags)
 	to.compare = m.compare
+
+	to.groups = nil
+	for tag, rg := range m.groups {
+		clone := NewRepeatingGroup(tag, rg.template)
+		if _, err := clone.Read(to.tagLookup[tag]); err == nil {
+			to.setRepeatingGroup(clone)
+		}
+	}
 }
 
 func (m *FieldMap) add(f field) {
@@ -221,13 +335,40 @@ func (m *FieldMap) add(f field) {
 	m.tagLookup[t] = f
 }
 
+//addRepeatingGroup adds the fields making up the repeating group rg along with its group structure
+func (m *FieldMap) addRepeatingGroup(f field, rg *RepeatingGroup) {
+	m.add(f)
+	m.setRepeatingGroup(rg)
+}
+
+//reusableRepeatingGroup returns a spare RepeatingGroup for tag and template if one is available, otherwise a new one
+func (m *FieldMap) reusableRepeatingGroup(tag Tag, template GroupTemplate) *RepeatingGroup {
+	if rg, ok := m.spareGroups[tag]; ok && sameTemplate(rg.template, template) {
+		delete(m.spareGroups, tag)
+		return rg
+	}
+
+	return NewRepeatingGroup(tag, template)
+}
+
+func sameTemplate(a, b GroupTemplate) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+func (m *FieldMap) setRepeatingGroup(rg *RepeatingGroup) {
+	if m.groups == nil {
+		m.groups = make(map[Tag]*RepeatingGroup)
+	}
+	m.groups[rg.tag] = rg
+}
+
 func (m *FieldMap) getOrCreate(tag Tag) field {
 	if f, ok := m.tagLookup[tag]; ok {
 		f = f[:1]
 		return f
 	}
 
-	f := make(field, 1)
+	f := m.storage.newField(1)
 	m.tagLookup[tag] = f
 	m.tags = append(m.tags, tag)
 	return f
@@ -236,7 +377,7 @@ func (m *FieldMap) getOrCreate(tag Tag) field {
 //Set is a setter for fields
 func (m *FieldMap) Set(field FieldWriter) *FieldMap {
 	f := m.getOrCreate(field.Tag())
-	initField(f, field.Tag(), field.Write())
+	m.storage.initTagValue(&f[0], field.Tag(), field.Write())
 	return m
 }
 
@@ -246,7 +387,12 @@ func (m *FieldMap) SetGroup(field FieldGroupWriter) *FieldMap {
 	if !ok {
 		m.tags = append(m.tags, field.Tag())
 	}
-	m.tagLookup[field.Tag()] = field.Write()
+	if rg, ok := field.(repeatingGroupItem); ok {
+		m.tagLookup[field.Tag()] = rg.repeatingGroup().writeTo(&m.storage)
+	} else {
+		m.tagLookup[field.Tag()] = field.Write()
+	}
+	delete(m.groups, field.Tag())
 	return m
 }
 
@@ -255,12 +401,14 @@ func (m *FieldMap) sortedTags() []Tag {
 	return m.tags
 }
 
-func (m FieldMap) write(buffer *bytes.Buffer) {
+//appendTo appends the fields of this FieldMap in order to b
+func (m *FieldMap) appendTo(b []byte) []byte {
 	for _, tag := range m.sortedTags() {
 		if f, ok := m.tagLookup[tag]; ok {
-			writeField(f, buffer)
+			b = appendField(b, f)
 		}
 	}
+	return b
 }
 
 func (m FieldMap) total() int {
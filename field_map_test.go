@@ -3,6 +3,7 @@ package quickfix
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -126,6 +127,53 @@ func TestFieldMap_BoolTypedSetAndGet(t *testing.T) {
 	assert.Equal(t, "N", s)
 }
 
+func TestFieldMap_DataTypeSetAndGet(t *testing.T) {
+	var fMap FieldMap
+	fMap.init()
+
+	fMap.SetChar(1, 'A')
+	c, err := fMap.GetChar(1)
+	assert.Nil(t, err)
+	assert.Equal(t, byte('A'), c)
+
+	fMap.SetMultipleValueString(2, "A", "B")
+	values, err := fMap.GetMultipleValueString(2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"A", "B"}, values)
+
+	fMap.SetField(3, FIXUTCDateOnly{time.Date(2016, time.February, 8, 0, 0, 0, 0, time.UTC)})
+	d, err := fMap.GetUTCDateOnly(3)
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2016, time.February, 8, 0, 0, 0, 0, time.UTC), d)
+
+	fMap.SetString(4, "20160208-17:07:16+05")
+	ts, err := fMap.GetTZTimestamp(4)
+	assert.Nil(t, err)
+	assert.True(t, time.Date(2016, time.February, 8, 12, 7, 16, 0, time.UTC).Equal(ts))
+
+	fMap.SetString(7, "20160208-12:07:16.123456")
+	tsp, precision, err := fMap.GetTimeWithPrecision(7)
+	assert.Nil(t, err)
+	assert.Equal(t, Micros, precision)
+	assert.True(t, time.Date(2016, time.February, 8, 12, 7, 16, 123456000, time.UTC).Equal(tsp))
+
+	fMap.SetString(8, "20160208-17:07:16.1234567891-05")
+	tsp, precision, err = fMap.GetTZTimestampWithPrecision(8)
+	assert.Nil(t, err)
+	assert.Equal(t, Picos, precision)
+	assert.True(t, time.Date(2016, time.February, 8, 22, 7, 16, 123456789, time.UTC).Equal(tsp))
+
+	fMap.SetField(5, FIXMonthYear{Year: 2016, Month: time.March, Week: 2})
+	my, err := fMap.GetMonthYear(5)
+	assert.Nil(t, err)
+	assert.Equal(t, FIXMonthYear{Year: 2016, Month: time.March, Week: 2}, my)
+
+	fMap.SetString(6, "AB")
+	_, err = fMap.GetChar(6)
+	assert.NotNil(t, err)
+	assert.Equal(t, rejectReasonIncorrectDataFormatForValue, err.RejectReason())
+}
+
 func TestFieldMap_CopyInto(t *testing.T) {
 	var fMapA FieldMap
 	fMapA.initWithOrdering(headerFieldOrdering)
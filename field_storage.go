@@ -0,0 +1,90 @@
+package quickfix
+
+import "strconv"
+
+const (
+	minTagValueChunk = 8
+	maxTagValueChunk = 256
+	minByteChunk     = 128
+	maxByteChunk     = 4096
+
+	//maxTagPrefixLen is the longest "tag=" prefix, for a 10 digit tag
+	maxTagPrefixLen = 11
+)
+
+//tagPrefixes are the precomputed "tag=" prefixes of the commonly used tags
+var tagPrefixes = func() (prefixes [1024][]byte) {
+	for tag := range prefixes {
+		prefixes[tag] = append(strconv.AppendInt(nil, int64(tag), 10), '=')
+	}
+	return
+}()
+
+//appendTagPrefix appends the "tag=" prefix of tag to b
+func appendTagPrefix(b []byte, tag Tag) []byte {
+	if tag >= 0 && int(tag) < len(tagPrefixes) {
+		return append(b, tagPrefixes[tag]...)
+	}
+
+	return append(strconv.AppendInt(b, int64(tag), 10), '=')
+}
+
+//fieldStorage allocates the TagValues and bytes of the fields set on a FieldMap in chunks. Storage is reused once
+//reset, so setting fields on a cleared FieldMap is allocation free in the steady state.
+//
+//Bytes handed out are never rewritten until reset, fields replaced before then take new storage.
+type fieldStorage struct {
+	tagValues []TagValue
+	bytes     []byte
+}
+
+func (s *fieldStorage) reset() {
+	s.tagValues = s.tagValues[:0]
+	s.bytes = s.bytes[:0]
+}
+
+//chunkSize grows chunks geometrically between min and max, but at least to need
+func chunkSize(current, need, min, max int) int {
+	size := 2 * current
+	if size < min {
+		size = min
+	}
+	if size > max {
+		size = max
+	}
+	if size < need {
+		size = need
+	}
+
+	return size
+}
+
+//newField returns a field of n TagValues
+func (s *fieldStorage) newField(n int) field {
+	if cap(s.tagValues)-len(s.tagValues) < n {
+		s.tagValues = make([]TagValue, 0, chunkSize(cap(s.tagValues), n, minTagValueChunk, maxTagValueChunk))
+	}
+
+	start := len(s.tagValues)
+	s.tagValues = s.tagValues[:start+n]
+	return s.tagValues[start : start+n : start+n]
+}
+
+//initTagValue initializes tv with a copy of value
+func (s *fieldStorage) initTagValue(tv *TagValue, tag Tag, value []byte) {
+	need := maxTagPrefixLen + len(value) + 1
+	if cap(s.bytes)-len(s.bytes) < need {
+		s.bytes = make([]byte, 0, chunkSize(cap(s.bytes), need, minByteChunk, maxByteChunk))
+	}
+
+	start := len(s.bytes)
+	b := appendTagPrefix(s.bytes, tag)
+	valueStart := len(b)
+	b = append(b, value...)
+	b = append(b, '\001')
+	s.bytes = b
+
+	tv.tag = tag
+	tv.value = b[valueStart : len(b)-1 : len(b)-1]
+	tv.bytes = b[start:len(b):len(b)]
+}
@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"path"
+	"strconv"
 
 	"github.com/quickfixgo/quickfix/config"
 )
@@ -17,13 +18,22 @@ type fileLog struct {
 const delim byte = 1
 
 var (
-	redacted = map[string]bool{"467": true, "2001": true, "2002": true, "554": true}
+	redacted = map[string]bool{"467": true, "2001": true, "2002": true}
 )
 
+func init() {
+	//logon credentials populated by the session are always masked
+	for _, tag := range credentialTags {
+		redacted[strconv.Itoa(int(tag))] = true
+	}
+}
+
 func (l fileLog) OnIncoming(msg []byte) {
 	msgType := getMsgType(msg)
 	if msgType == "W" || msgType == "X" {
 		return // don't save price data
+	} else if msgType == "A" { // LOGON: Password (554), NewPassword (925)
+		redactTags(redacted, msg)
 	}
 	replaceDelimiter(msg)
 	l.messageLogger.Print(string(msg))
@@ -33,7 +43,7 @@ func (l fileLog) OnOutgoing(msg []byte) {
 	msgType := getMsgType(msg)
 	if msgType == "W" || msgType == "X" {
 		return // don't save price data
-	} else if msgType == "D" || msgType == "A" { // NewOrderSingle: API KEY (467), SECRET (2001), PASS (2002) | LOGON: Password (554)
+	} else if msgType == "D" || msgType == "A" { // NewOrderSingle: API KEY (467), SECRET (2001), PASS (2002) | LOGON: Password (554), NewPassword (925)
 		redactTags(redacted, msg)
 	}
 	replaceDelimiter(msg)
@@ -108,12 +118,15 @@ func (l fileLog) OnEventf(format string, v ...interface{}) {
 type fileLogFactory struct {
 	globalLogPath   string
 	sessionLogPaths map[SessionID]string
+
+	//used to resolve log paths of sessions created from acceptor templates
+	settings *Settings
 }
 
 //NewFileLogFactory creates an instance of LogFactory that writes messages and events to file.
 //The location of global and session log files is configured via FileLogPath.
 func NewFileLogFactory(settings *Settings) (LogFactory, error) {
-	logFactory := fileLogFactory{}
+	logFactory := fileLogFactory{settings: settings}
 
 	var err error
 	if logFactory.globalLogPath, err = settings.GlobalSettings().Setting(config.FileLogPath); err != nil {
@@ -168,6 +181,16 @@ func (f fileLogFactory) Create() (Log, error) {
 func (f fileLogFactory) CreateSessionLog(sessionID SessionID) (Log, error) {
 	logPath, ok := f.sessionLogPaths[sessionID]
 
+	if !ok && f.settings != nil {
+		var templateSettings *SessionSettings
+		if templateSettings, ok = f.settings.sessionSettingsFor(sessionID); ok {
+			var err error
+			if logPath, err = templateSettings.Setting(config.FileLogPath); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if !ok {
 		return nil, fmt.Errorf("logger not defined for %v", sessionID)
 	}
@@ -195,3 +195,12 @@ func TestReplaceDelim(t *testing.T) {
 		t.Errorf("Failed to replace delimiter with pipe |")
 	}
 }
+
+func TestRedactCredentialTags(t *testing.T) {
+	logon := []byte("8=FIX.4.49=8835=A34=149=TW52=20191218-23:15:42.24156=ISLD98=0108=30553=user554=secret925=rotated10=054")
+	expectedLogon := []byte("8=FIX.4.49=8835=A34=149=TW52=20191218-23:15:42.24156=ISLD98=0108=30553=user554=******925=*******10=054")
+	redactTags(redacted, logon)
+	if string(expectedLogon) != string(logon) {
+		t.Errorf("Incorrect Logon credential redaction.\nReceived: %s\nExpected: %s", string(logon), string(expectedLogon))
+	}
+}
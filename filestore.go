@@ -42,7 +42,7 @@ func NewFileStoreFactory(settings *Settings) MessageStoreFactory {
 
 // Create creates a new FileStore implementation of the MessageStore interface
 func (f fileStoreFactory) Create(sessionID SessionID) (msgStore MessageStore, err error) {
-	sessionSettings, ok := f.settings.SessionSettings()[sessionID]
+	sessionSettings, ok := f.settings.sessionSettingsFor(sessionID)
 	if !ok {
 		return nil, fmt.Errorf("unknown session: %v", sessionID)
 	}
@@ -249,6 +249,10 @@ func (store *fileStore) CreationTime() time.Time {
 	return store.cache.CreationTime()
 }
 
+func (store *fileStore) setClock(clock Clock) {
+	store.cache.setClock(clock)
+}
+
 func (store *fileStore) SaveMessage(seqNum int, msg []byte) error {
 	offset, err := store.bodyFile.Seek(0, os.SEEK_END)
 	if err != nil {
@@ -0,0 +1,23 @@
+package quickfix
+
+import "errors"
+
+//FIXChar is a FIX Char value, a single character. Implements FieldValue
+type FIXChar byte
+
+func (f FIXChar) String() string {
+	return string(rune(f))
+}
+
+func (f *FIXChar) Read(bytes []byte) error {
+	if len(bytes) != 1 {
+		return errors.New("Invalid Value for Char: " + string(bytes))
+	}
+
+	*f = FIXChar(bytes[0])
+	return nil
+}
+
+func (f FIXChar) Write() []byte {
+	return []byte{byte(f)}
+}
@@ -0,0 +1,21 @@
+package quickfix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXCharRead(t *testing.T) {
+	var field FIXChar
+	assert.Nil(t, field.Read([]byte("1")))
+	assert.Equal(t, FIXChar('1'), field)
+	assert.Equal(t, "1", field.String())
+
+	assert.NotNil(t, field.Read([]byte("12")))
+	assert.NotNil(t, field.Read([]byte("")))
+}
+
+func TestFIXCharWrite(t *testing.T) {
+	assert.Equal(t, []byte("Y"), FIXChar('Y').Write())
+}
@@ -0,0 +1,14 @@
+package quickfix
+
+//FIXData is a FIX Data value, raw bytes that may contain the SOH delimiter. Implements FieldValue.
+//The length of a Data field is carried by the Length field preceding it.
+type FIXData []byte
+
+func (f *FIXData) Read(bytes []byte) error {
+	*f = append((*f)[:0], bytes...)
+	return nil
+}
+
+func (f FIXData) Write() []byte {
+	return []byte(f)
+}
@@ -0,0 +1,22 @@
+package quickfix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXDataRead(t *testing.T) {
+	raw := []byte("a\001b=c")
+
+	var field FIXData
+	assert.Nil(t, field.Read(raw))
+	assert.Equal(t, FIXData("a\001b=c"), field)
+
+	raw[0] = 'z'
+	assert.Equal(t, FIXData("a\001b=c"), field, "read values do not alias the raw message")
+}
+
+func TestFIXDataWrite(t *testing.T) {
+	assert.Equal(t, []byte("a\001b"), FIXData("a\001b").Write())
+}
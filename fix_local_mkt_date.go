@@ -0,0 +1,25 @@
+package quickfix
+
+import (
+	"errors"
+	"time"
+)
+
+//FIXLocalMktDate is a FIX LocalMktDate value, a date in the local time of the market center. Implements FieldValue.
+//The date is read as midnight UTC, and written from the date of Time in its own location.
+type FIXLocalMktDate struct {
+	time.Time
+}
+
+func (f *FIXLocalMktDate) Read(bytes []byte) (err error) {
+	if len(bytes) != len(utcDateOnlyFormat) {
+		return errors.New("Invalid Value for LocalMktDate: " + string(bytes))
+	}
+
+	f.Time, err = time.Parse(utcDateOnlyFormat, string(bytes))
+	return
+}
+
+func (f FIXLocalMktDate) Write() []byte {
+	return []byte(f.Format(utcDateOnlyFormat))
+}
@@ -0,0 +1,23 @@
+package quickfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXLocalMktDateRead(t *testing.T) {
+	var field FIXLocalMktDate
+	assert.Nil(t, field.Read([]byte("20160208")))
+	assert.Equal(t, time.Date(2016, time.February, 8, 0, 0, 0, 0, time.UTC), field.Time)
+
+	assert.NotNil(t, field.Read([]byte("201602")))
+	assert.NotNil(t, field.Read([]byte("2016020X")))
+}
+
+func TestFIXLocalMktDateWrite(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	field := FIXLocalMktDate{time.Date(2016, time.February, 8, 22, 0, 0, 0, est)}
+	assert.Equal(t, "20160208", string(field.Write()), "the date is written in the location of the time")
+}
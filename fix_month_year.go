@@ -0,0 +1,68 @@
+package quickfix
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+//FIXMonthYear is a FIX MonthYear value, implements FieldValue. It is written as YYYYMM, or with either a day of
+//month as YYYYMMDD or a week of month as YYYYMMwN.
+type FIXMonthYear struct {
+	Year  int
+	Month time.Month
+
+	//Day is the optional day of month, 1-31
+	Day int
+
+	//Week is the optional week of month, 1-5
+	Week int
+}
+
+func (f *FIXMonthYear) Read(bytes []byte) error {
+	invalid := errors.New("Invalid Value for MonthYear: " + string(bytes))
+	if len(bytes) != 6 && len(bytes) != 8 {
+		return invalid
+	}
+
+	year, err := parseUInt(bytes[0:4])
+	if err != nil {
+		return invalid
+	}
+
+	month, err := parseUInt(bytes[4:6])
+	if err != nil || month < 1 || month > 12 {
+		return invalid
+	}
+
+	var day, week int
+	switch {
+	case len(bytes) == 6:
+	case bytes[6] == 'w':
+		if week, err = parseUInt(bytes[7:8]); err != nil || week < 1 || week > 5 {
+			return invalid
+		}
+	default:
+		if day, err = parseUInt(bytes[6:8]); err != nil || day < 1 || day > 31 {
+			return invalid
+		}
+	}
+
+	*f = FIXMonthYear{Year: year, Month: time.Month(month), Day: day, Week: week}
+	return nil
+}
+
+func (f FIXMonthYear) Write() []byte {
+	switch {
+	case f.Day != 0:
+		return []byte(fmt.Sprintf("%04d%02d%02d", f.Year, int(f.Month), f.Day))
+	case f.Week != 0:
+		return []byte(fmt.Sprintf("%04d%02dw%d", f.Year, int(f.Month), f.Week))
+	}
+
+	return []byte(fmt.Sprintf("%04d%02d", f.Year, int(f.Month)))
+}
+
+func (f FIXMonthYear) String() string {
+	return string(f.Write())
+}
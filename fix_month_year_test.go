@@ -0,0 +1,44 @@
+package quickfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXMonthYearRead(t *testing.T) {
+	var tests = []struct {
+		bytes       string
+		expected    FIXMonthYear
+		expectError bool
+	}{
+		{"201602", FIXMonthYear{Year: 2016, Month: time.February}, false},
+		{"20160215", FIXMonthYear{Year: 2016, Month: time.February, Day: 15}, false},
+		{"201602w3", FIXMonthYear{Year: 2016, Month: time.February, Week: 3}, false},
+		{"201613", FIXMonthYear{}, true},
+		{"20160232", FIXMonthYear{}, true},
+		{"201602w6", FIXMonthYear{}, true},
+		{"2016021", FIXMonthYear{}, true},
+		{"2016", FIXMonthYear{}, true},
+	}
+
+	for _, test := range tests {
+		var field FIXMonthYear
+		err := field.Read([]byte(test.bytes))
+		if test.expectError {
+			assert.NotNil(t, err, test.bytes)
+			continue
+		}
+
+		assert.Nil(t, err, test.bytes)
+		assert.Equal(t, test.expected, field)
+		assert.Equal(t, test.bytes, string(field.Write()), "round trip of %v", test.bytes)
+	}
+}
+
+func TestFIXMonthYearWrite(t *testing.T) {
+	assert.Equal(t, "200901", string(FIXMonthYear{Year: 2009, Month: time.January}.Write()))
+	assert.Equal(t, "20090105", string(FIXMonthYear{Year: 2009, Month: time.January, Day: 5}.Write()))
+	assert.Equal(t, "200901w1", string(FIXMonthYear{Year: 2009, Month: time.January, Week: 1}.Write()))
+}
@@ -0,0 +1,28 @@
+package quickfix
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+)
+
+//FIXMultipleValueString is a FIX MultipleValueString value, space delimited string values. Implements FieldValue
+type FIXMultipleValueString []string
+
+func (f *FIXMultipleValueString) Read(b []byte) error {
+	values := bytes.Split(b, []byte(" "))
+
+	*f = make(FIXMultipleValueString, len(values))
+	for i, v := range values {
+		if len(v) == 0 {
+			return errors.New("Invalid Value for MultipleValueString: " + string(b))
+		}
+		(*f)[i] = string(v)
+	}
+
+	return nil
+}
+
+func (f FIXMultipleValueString) Write() []byte {
+	return []byte(strings.Join(f, " "))
+}
@@ -0,0 +1,38 @@
+package quickfix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXMultipleValueStringRead(t *testing.T) {
+	var tests = []struct {
+		bytes       string
+		expected    FIXMultipleValueString
+		expectError bool
+	}{
+		{"A", FIXMultipleValueString{"A"}, false},
+		{"A B 1", FIXMultipleValueString{"A", "B", "1"}, false},
+		{"A  B", nil, true},
+		{"A ", nil, true},
+		{"", nil, true},
+	}
+
+	for _, test := range tests {
+		var field FIXMultipleValueString
+		err := field.Read([]byte(test.bytes))
+		if test.expectError {
+			assert.NotNil(t, err, test.bytes)
+			continue
+		}
+
+		assert.Nil(t, err, test.bytes)
+		assert.Equal(t, test.expected, field)
+	}
+}
+
+func TestFIXMultipleValueStringWrite(t *testing.T) {
+	assert.Equal(t, "A", string(FIXMultipleValueString{"A"}.Write()))
+	assert.Equal(t, "A B 1", string(FIXMultipleValueString{"A", "B", "1"}.Write()))
+}
@@ -0,0 +1,36 @@
+package quickfix
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	tzTimeOnlyMinutesFormat = "15:04"
+	tzTimeOnlySecondsFormat = "15:04:05"
+)
+
+//FIXTZTimeOnly is a FIX TZTimeOnly value, a time of day with its offset from UTC. Implements FieldValue.
+//The time is read on January 1st of year 0 in a fixed zone of the received offset.
+type FIXTZTimeOnly struct {
+	time.Time
+	Precision TimestampPrecision
+
+	//Digits is the count of fractional digits written, as for FIXUTCTimestamp
+	Digits int
+
+	//Picoseconds beyond the nanoseconds of Time, only written with Picos precision
+	Picoseconds int
+}
+
+func (f *FIXTZTimeOnly) Read(b []byte) (err error) {
+	if f.Time, f.Precision, f.Digits, f.Picoseconds, err = parseTZTime(b, len(tzTimeOnlyMinutesFormat), tzTimeOnlyMinutesFormat, tzTimeOnlySecondsFormat); err != nil {
+		return errors.New("Invalid Value for TZTimeOnly: " + string(b))
+	}
+
+	return
+}
+
+func (f FIXTZTimeOnly) Write() []byte {
+	return f.AppendFormat(appendTime(nil, f.Time, tzTimeOnlySecondsFormat, f.Precision, f.Digits, f.Picoseconds), tzOffsetFormat)
+}
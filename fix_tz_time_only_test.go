@@ -0,0 +1,45 @@
+package quickfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXTZTimeOnlyRead(t *testing.T) {
+	var tests = []struct {
+		bytes          string
+		expected       time.Time
+		expectedOffset int
+		expectError    bool
+	}{
+		{"07:39Z", time.Date(0, time.January, 1, 7, 39, 0, 0, time.UTC), 0, false},
+		{"02:39-05", time.Date(0, time.January, 1, 7, 39, 0, 0, time.UTC), -5 * 60 * 60, false},
+		{"13:09+05:30", time.Date(0, time.January, 1, 7, 39, 0, 0, time.UTC), 5*60*60 + 30*60, false},
+		{"07:39:12.123Z", time.Date(0, time.January, 1, 7, 39, 12, 123000000, time.UTC), 0, false},
+		{"07:39:12", time.Time{}, 0, true},
+		{"07Z", time.Time{}, 0, true},
+	}
+
+	for _, test := range tests {
+		var field FIXTZTimeOnly
+		err := field.Read([]byte(test.bytes))
+		if test.expectError {
+			assert.NotNil(t, err, test.bytes)
+			continue
+		}
+
+		assert.Nil(t, err, test.bytes)
+		assert.True(t, test.expected.Equal(field.Time), "got %v want %v", field.Time, test.expected)
+		_, offset := field.Zone()
+		assert.Equal(t, test.expectedOffset, offset, test.bytes)
+	}
+}
+
+func TestFIXTZTimeOnlyWrite(t *testing.T) {
+	ts := time.Date(0, time.January, 1, 13, 9, 12, 0, time.FixedZone("", 5*60*60+30*60))
+
+	assert.Equal(t, "13:09:12+05:30", string(FIXTZTimeOnly{Time: ts, Precision: Seconds}.Write()))
+	assert.Equal(t, "07:39:12.000Z", string(FIXTZTimeOnly{Time: ts.UTC()}.Write()))
+}
@@ -0,0 +1,82 @@
+package quickfix
+
+import (
+	"bytes"
+	"errors"
+	"time"
+)
+
+const (
+	tzTimestampMinutesFormat = "20060102-15:04"
+	tzTimestampSecondsFormat = "20060102-15:04:05"
+	tzOffsetFormat           = "Z07:00"
+)
+
+//FIXTZTimestamp is a FIX TZTimestamp value, a timestamp with its offset from UTC. Implements FieldValue.
+//The time is read in a fixed zone of the received offset, with fractional seconds as for FIXUTCTimestamp, and written
+//with the offset of its location.
+type FIXTZTimestamp struct {
+	time.Time
+	Precision TimestampPrecision
+
+	//Digits is the count of fractional digits written, as for FIXUTCTimestamp
+	Digits int
+
+	//Picoseconds beyond the nanoseconds of Time, only written with Picos precision
+	Picoseconds int
+}
+
+func (f *FIXTZTimestamp) Read(b []byte) (err error) {
+	if f.Time, f.Precision, f.Digits, f.Picoseconds, err = parseTZTime(b, len("20060102-"), tzTimestampMinutesFormat, tzTimestampSecondsFormat); err != nil {
+		return errors.New("Invalid Value for TZTimestamp: " + string(b))
+	}
+
+	return
+}
+
+func (f FIXTZTimestamp) Write() []byte {
+	return f.AppendFormat(appendTime(nil, f.Time, tzTimestampSecondsFormat, f.Precision, f.Digits, f.Picoseconds), tzOffsetFormat)
+}
+
+//parseTZTime parses a time of minutesFormat or secondsFormat with optional fractional seconds, followed by an offset
+//from UTC of Z, ±hh or ±hh:mm found at or after offsetFrom
+func parseTZTime(b []byte, offsetFrom int, minutesFormat, secondsFormat string) (t time.Time, precision TimestampPrecision, digits, picos int, err error) {
+	if len(b) <= offsetFrom {
+		return t, precision, digits, picos, errors.New("missing offset")
+	}
+
+	offsetIndex := bytes.IndexAny(b[offsetFrom:], "Z+-")
+	if offsetIndex == -1 {
+		return t, precision, digits, picos, errors.New("missing offset")
+	}
+	offsetIndex += offsetFrom
+
+	value, offset := b[:offsetIndex], b[offsetIndex:]
+	switch {
+	case len(offset) == 1 && offset[0] == 'Z':
+	case len(offset) == 3:
+		offset = append(offset[:3:3], ":00"...)
+	case len(offset) == 6 && offset[0] != 'Z':
+	default:
+		return t, precision, digits, picos, errors.New("invalid offset")
+	}
+
+	layout, nanos := minutesFormat, 0
+	if len(value) == len(minutesFormat) {
+		precision = Seconds
+	} else if len(value) < len(secondsFormat) {
+		return t, precision, digits, picos, errors.New("invalid time")
+	} else {
+		if nanos, picos, precision, digits, err = parseFraction(value[len(secondsFormat):]); err != nil {
+			return
+		}
+		layout, value = secondsFormat, value[:len(secondsFormat)]
+	}
+
+	if t, err = time.Parse(layout+tzOffsetFormat, string(value)+string(offset)); err != nil {
+		return
+	}
+
+	t = t.Add(time.Duration(nanos))
+	return
+}
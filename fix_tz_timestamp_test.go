@@ -0,0 +1,66 @@
+package quickfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXTZTimestampRead(t *testing.T) {
+	var tests = []struct {
+		bytes             string
+		expected          time.Time
+		expectedOffset    int
+		expectedPrecision TimestampPrecision
+		expectError       bool
+	}{
+		{"20060901-07:39Z", time.Date(2006, time.September, 1, 7, 39, 0, 0, time.UTC), 0, Seconds, false},
+		{"20060901-02:39-05", time.Date(2006, time.September, 1, 7, 39, 0, 0, time.UTC), -5 * 60 * 60, Seconds, false},
+		{"20060901-15:39+08", time.Date(2006, time.September, 1, 7, 39, 0, 0, time.UTC), 8 * 60 * 60, Seconds, false},
+		{"20060901-13:09+05:30", time.Date(2006, time.September, 1, 7, 39, 0, 0, time.UTC), 5*60*60 + 30*60, Seconds, false},
+		{"20060901-07:39:12Z", time.Date(2006, time.September, 1, 7, 39, 12, 0, time.UTC), 0, Seconds, false},
+		{"20060901-07:39:12.123Z", time.Date(2006, time.September, 1, 7, 39, 12, 123000000, time.UTC), 0, Millis, false},
+		{"20060901-02:39:12.123456789-05:00", time.Date(2006, time.September, 1, 7, 39, 12, 123456789, time.UTC), -5 * 60 * 60, Nanos, false},
+		{"20060901-07:39:12", time.Time{}, 0, Millis, true},
+		{"20060901-07:39:12+5", time.Time{}, 0, Millis, true},
+		{"20060901-07:39:12.12Z", time.Date(2006, time.September, 1, 7, 39, 12, 120000000, time.UTC), 0, Millis, false},
+		{"20060901-07:39:12.123456789012+01", time.Date(2006, time.September, 1, 6, 39, 12, 123456789, time.UTC), 60 * 60, Picos, false},
+		{"20060901-07:39:12.1234567890123Z", time.Time{}, 0, Millis, true},
+		{"20060901-07:39:12.Z", time.Time{}, 0, Millis, true},
+		{"20060901", time.Time{}, 0, Millis, true},
+	}
+
+	for _, test := range tests {
+		var field FIXTZTimestamp
+		err := field.Read([]byte(test.bytes))
+		if test.expectError {
+			assert.NotNil(t, err, test.bytes)
+			continue
+		}
+
+		assert.Nil(t, err, test.bytes)
+		assert.True(t, test.expected.Equal(field.Time), "got %v want %v", field.Time, test.expected)
+		_, offset := field.Zone()
+		assert.Equal(t, test.expectedOffset, offset, test.bytes)
+		assert.Equal(t, test.expectedPrecision, field.Precision, test.bytes)
+	}
+}
+
+func TestFIXTZTimestampWrite(t *testing.T) {
+	ts := time.Date(2006, time.September, 1, 7, 39, 12, 123456789, time.UTC)
+
+	var tests = []struct {
+		field    FIXTZTimestamp
+		expected string
+	}{
+		{FIXTZTimestamp{Time: ts, Precision: Seconds}, "20060901-07:39:12Z"},
+		{FIXTZTimestamp{Time: ts}, "20060901-07:39:12.123Z"},
+		{FIXTZTimestamp{Time: ts.In(time.FixedZone("", 5*60*60+30*60)), Precision: Micros}, "20060901-13:09:12.123456+05:30"},
+		{FIXTZTimestamp{Time: ts.In(time.FixedZone("", -5*60*60)), Precision: Nanos}, "20060901-02:39:12.123456789-05:00"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, string(test.field.Write()))
+	}
+}
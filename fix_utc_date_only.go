@@ -0,0 +1,26 @@
+package quickfix
+
+import (
+	"errors"
+	"time"
+)
+
+const utcDateOnlyFormat = "20060102"
+
+//FIXUTCDateOnly is a FIX UTCDateOnly value, a date in UTC without a time of day. Implements FieldValue
+type FIXUTCDateOnly struct {
+	time.Time
+}
+
+func (f *FIXUTCDateOnly) Read(bytes []byte) (err error) {
+	if len(bytes) != len(utcDateOnlyFormat) {
+		return errors.New("Invalid Value for UTCDateOnly: " + string(bytes))
+	}
+
+	f.Time, err = time.Parse(utcDateOnlyFormat, string(bytes))
+	return
+}
+
+func (f FIXUTCDateOnly) Write() []byte {
+	return []byte(f.UTC().Format(utcDateOnlyFormat))
+}
@@ -0,0 +1,39 @@
+package quickfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXUTCDateOnlyRead(t *testing.T) {
+	var tests = []struct {
+		bytes       string
+		expected    time.Time
+		expectError bool
+	}{
+		{"20160208", time.Date(2016, time.February, 8, 0, 0, 0, 0, time.UTC), false},
+		{"2016020", time.Time{}, true},
+		{"20161308", time.Time{}, true},
+		{"2016-02-08", time.Time{}, true},
+	}
+
+	for _, test := range tests {
+		var field FIXUTCDateOnly
+		err := field.Read([]byte(test.bytes))
+		if test.expectError {
+			assert.NotNil(t, err, test.bytes)
+			continue
+		}
+
+		assert.Nil(t, err, test.bytes)
+		assert.True(t, test.expected.Equal(field.Time), "got %v want %v", field.Time, test.expected)
+	}
+}
+
+func TestFIXUTCDateOnlyWrite(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	field := FIXUTCDateOnly{time.Date(2016, time.February, 8, 22, 0, 0, 0, est)}
+	assert.Equal(t, "20160209", string(field.Write()))
+}
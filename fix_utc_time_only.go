@@ -0,0 +1,43 @@
+package quickfix
+
+import (
+	"errors"
+	"time"
+)
+
+const utcTimeOnlyFormat = "15:04:05"
+
+//FIXUTCTimeOnly is a FIX UTCTimeOnly value, a time of day in UTC. Implements FieldValue.
+//The time is read on January 1st of year 0, with fractional seconds as for FIXUTCTimestamp.
+type FIXUTCTimeOnly struct {
+	time.Time
+	Precision TimestampPrecision
+
+	//Digits is the count of fractional digits written, as for FIXUTCTimestamp
+	Digits int
+
+	//Picoseconds beyond the nanoseconds of Time, only written with Picos precision
+	Picoseconds int
+}
+
+func (f *FIXUTCTimeOnly) Read(bytes []byte) (err error) {
+	if len(bytes) < len(utcTimeOnlyFormat) {
+		return errors.New("Invalid Value for UTCTimeOnly: " + string(bytes))
+	}
+
+	var nanos int
+	if nanos, f.Picoseconds, f.Precision, f.Digits, err = parseFraction(bytes[len(utcTimeOnlyFormat):]); err != nil {
+		return errors.New("Invalid Value for UTCTimeOnly: " + string(bytes))
+	}
+
+	if f.Time, err = time.Parse(utcTimeOnlyFormat, string(bytes[:len(utcTimeOnlyFormat)])); err != nil {
+		return
+	}
+
+	f.Time = f.Time.Add(time.Duration(nanos))
+	return
+}
+
+func (f FIXUTCTimeOnly) Write() []byte {
+	return appendTime(nil, f.UTC(), utcTimeOnlyFormat, f.Precision, f.Digits, f.Picoseconds)
+}
@@ -0,0 +1,58 @@
+package quickfix
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFIXUTCTimeOnlyRead(t *testing.T) {
+	var tests = []struct {
+		bytes             string
+		expected          time.Time
+		expectedPrecision TimestampPrecision
+		expectError       bool
+	}{
+		{"22:07:16", time.Date(0, time.January, 1, 22, 7, 16, 0, time.UTC), Seconds, false},
+		{"22:07:16.310", time.Date(0, time.January, 1, 22, 7, 16, 310000000, time.UTC), Millis, false},
+		{"22:07:16.123455", time.Date(0, time.January, 1, 22, 7, 16, 123455000, time.UTC), Micros, false},
+		{"22:07:16.954123123", time.Date(0, time.January, 1, 22, 7, 16, 954123123, time.UTC), Nanos, false},
+		{"22:07", time.Time{}, Millis, true},
+		{"22:07:16.31", time.Date(0, time.January, 1, 22, 7, 16, 310000000, time.UTC), Millis, false},
+		{"22:07:16.3x", time.Time{}, Millis, true},
+		{"25:07:16", time.Time{}, Millis, true},
+	}
+
+	for _, test := range tests {
+		var field FIXUTCTimeOnly
+		err := field.Read([]byte(test.bytes))
+		if test.expectError {
+			assert.NotNil(t, err, test.bytes)
+			continue
+		}
+
+		assert.Nil(t, err, test.bytes)
+		assert.True(t, test.expected.Equal(field.Time), "got %v want %v", field.Time, test.expected)
+		assert.Equal(t, test.expectedPrecision, field.Precision, test.bytes)
+	}
+}
+
+func TestFIXUTCTimeOnlyWrite(t *testing.T) {
+	ts := time.Date(2016, time.February, 8, 22, 7, 16, 954123123, time.UTC)
+
+	var tests = []struct {
+		precision TimestampPrecision
+		expected  string
+	}{
+		{Millis, "22:07:16.954"},
+		{Seconds, "22:07:16"},
+		{Micros, "22:07:16.954123"},
+		{Nanos, "22:07:16.954123123"},
+	}
+
+	for _, test := range tests {
+		field := FIXUTCTimeOnly{Time: ts, Precision: test.precision}
+		assert.Equal(t, test.expected, string(field.Write()))
+	}
+}
@@ -14,58 +14,136 @@ const (
 	Seconds
 	Micros
 	Nanos
+	Picos
 )
 
-//FIXUTCTimestamp is a FIX UTC Timestamp value, implements FieldValue
+//FIXUTCTimestamp is a FIX UTC Timestamp value, implements FieldValue.
+//
+//Read accepts up to 12 fractional digits. Precision is set to the smallest precision holding the digits received and
+//Digits to their count, so that writing the value back preserves them. time.Time holds nanoseconds, digits beyond are
+//kept in Picoseconds.
 type FIXUTCTimestamp struct {
 	time.Time
 	Precision TimestampPrecision
+
+	//Digits is the count of fractional digits written, used when within Precision. Zero writes all digits of Precision
+	Digits int
+
+	//Picoseconds beyond the nanoseconds of Time, only written with Picos precision
+	Picoseconds int
 }
 
-const (
-	utcTimestampMillisFormat  = "20060102-15:04:05.000"
-	utcTimestampSecondsFormat = "20060102-15:04:05"
-	utcTimestampMicrosFormat  = "20060102-15:04:05.000000"
-	utcTimestampNanosFormat   = "20060102-15:04:05.000000000"
-)
+const utcTimestampSecondsFormat = "20060102-15:04:05"
 
 func (f *FIXUTCTimestamp) Read(bytes []byte) (err error) {
-	switch len(bytes) {
-	//seconds
-	case 17:
-		f.Time, err = time.Parse(utcTimestampSecondsFormat, string(bytes))
-		f.Precision = Seconds
-
-	//millis
-	case 21:
-		f.Time, err = time.Parse(utcTimestampMillisFormat, string(bytes))
-		f.Precision = Millis
-
-	// micros
-	case 24:
-		f.Time, err = time.Parse(utcTimestampMicrosFormat, string(bytes))
-		f.Precision = Micros
-
-	// nanos
-	case 27:
-		f.Time, err = time.Parse(utcTimestampNanosFormat, string(bytes))
-		f.Precision = Nanos
-
-	default:
-		err = errors.New("Invalid Value for Timestamp: " + string(bytes))
+	if len(bytes) < len(utcTimestampSecondsFormat) {
+		return errors.New("Invalid Value for Timestamp: " + string(bytes))
 	}
 
+	var nanos int
+	if nanos, f.Picoseconds, f.Precision, f.Digits, err = parseFraction(bytes[len(utcTimestampSecondsFormat):]); err != nil {
+		return errors.New("Invalid Value for Timestamp: " + string(bytes))
+	}
+
+	if f.Time, err = time.Parse(utcTimestampSecondsFormat, string(bytes[:len(utcTimestampSecondsFormat)])); err != nil {
+		return
+	}
+
+	f.Time = f.Time.Add(time.Duration(nanos))
 	return
 }
 
 func (f FIXUTCTimestamp) Write() []byte {
-	switch f.Precision {
+	return appendTime(nil, f.UTC(), utcTimestampSecondsFormat, f.Precision, f.Digits, f.Picoseconds)
+}
+
+//Truncated returns true if the value read had digits beyond the nanoseconds held by Time
+func (f FIXUTCTimestamp) Truncated() bool {
+	return f.Picoseconds != 0
+}
+
+//parseFraction parses optional fractional seconds of up to 12 digits, returning the nanoseconds, the picoseconds
+//beyond them, the precision holding the digits and their count
+func parseFraction(bytes []byte) (nanos, picos int, precision TimestampPrecision, digits int, err error) {
+	if len(bytes) == 0 {
+		return 0, 0, Seconds, 0, nil
+	}
+
+	fraction := bytes[1:]
+	if bytes[0] != '.' || len(fraction) == 0 || len(fraction) > 12 {
+		return 0, 0, precision, 0, errors.New("invalid fractional seconds")
+	}
+
+	for i := 0; i < 12; i++ {
+		var d int
+		if i < len(fraction) {
+			if !isDecimal(fraction[i]) {
+				return 0, 0, precision, 0, errors.New("invalid fractional seconds")
+			}
+			d = int(fraction[i] - '0')
+		}
+
+		if i < 9 {
+			nanos = nanos*10 + d
+		} else {
+			picos = picos*10 + d
+		}
+	}
+
+	return nanos, picos, fractionPrecision(len(fraction)), len(fraction), nil
+}
+
+//fractionPrecision returns the smallest precision holding digits fractional digits
+func fractionPrecision(digits int) TimestampPrecision {
+	switch {
+	case digits == 0:
+		return Seconds
+	case digits <= 3:
+		return Millis
+	case digits <= 6:
+		return Micros
+	case digits <= 9:
+		return Nanos
+	}
+
+	return Picos
+}
+
+//precisionDigits returns the count of fractional digits of precision
+func precisionDigits(precision TimestampPrecision) int {
+	switch precision {
 	case Seconds:
-		return []byte(f.UTC().Format(utcTimestampSecondsFormat))
+		return 0
 	case Micros:
-		return []byte(f.UTC().Format(utcTimestampMicrosFormat))
+		return 6
 	case Nanos:
-		return []byte(f.UTC().Format(utcTimestampNanosFormat))
+		return 9
+	case Picos:
+		return 12
 	}
-	return []byte(f.UTC().Format(utcTimestampMillisFormat))
+
+	return 3
+}
+
+//appendTime appends t formatted with a layout ending in seconds, followed by the fractional seconds of precision. digits
+//within precision limits the fractional digits written, as read
+func appendTime(b []byte, t time.Time, layout string, precision TimestampPrecision, digits, picos int) []byte {
+	b = t.AppendFormat(b, layout)
+	if digits == 0 || fractionPrecision(digits) != precision {
+		digits = precisionDigits(precision)
+	}
+	if digits == 0 {
+		return b
+	}
+
+	b = append(b, '.')
+	nanos := t.Nanosecond()
+	for i, scale := 0, 100000000; i < digits && i < 9; i, scale = i+1, scale/10 {
+		b = append(b, byte('0'+nanos/scale%10))
+	}
+	for i, scale := 9, 100; i < digits; i, scale = i+1, scale/10 {
+		b = append(b, byte('0'+picos/scale%10))
+	}
+
+	return b
 }
@@ -19,6 +19,7 @@ func TestFIXUTCTimestampWrite(t *testing.T) {
 		{quickfix.Seconds, []byte("20160208-22:07:16")},
 		{quickfix.Micros, []byte("20160208-22:07:16.954123")},
 		{quickfix.Nanos, []byte("20160208-22:07:16.954123123")},
+		{quickfix.Picos, []byte("20160208-22:07:16.954123123000")},
 	}
 
 	for _, test := range tests {
@@ -44,6 +45,10 @@ func TestFIXUTCTimestampRead(t *testing.T) {
 		{"20160208-22:07:16", time.Date(2016, time.February, 8, 22, 7, 16, 0, time.UTC), quickfix.Seconds},
 		{"20160208-22:07:16.123455", time.Date(2016, time.February, 8, 22, 7, 16, 123455000, time.UTC), quickfix.Micros},
 		{"20160208-22:07:16.954123123", time.Date(2016, time.February, 8, 22, 7, 16, 954123123, time.UTC), quickfix.Nanos},
+		{"20160208-22:07:16.9541231231", time.Date(2016, time.February, 8, 22, 7, 16, 954123123, time.UTC), quickfix.Picos},
+		{"20160208-22:07:16.954123123456", time.Date(2016, time.February, 8, 22, 7, 16, 954123123, time.UTC), quickfix.Picos},
+		{"20160208-22:07:16.3", time.Date(2016, time.February, 8, 22, 7, 16, 300000000, time.UTC), quickfix.Millis},
+		{"20160208-22:07:16.95412", time.Date(2016, time.February, 8, 22, 7, 16, 954120000, time.UTC), quickfix.Micros},
 	}
 
 	for _, test := range tests {
@@ -61,3 +66,94 @@ func TestFIXUTCTimestampRead(t *testing.T) {
 		}
 	}
 }
+
+func TestFIXUTCTimestampReadInvalid(t *testing.T) {
+	for _, timeStr := range []string{
+		"20160208-22:07",
+		"20160208-22:07:16.",
+		"20160208-22:07:16.1234567890123",
+		"20160208-22:07:16.12a",
+		"20160208-22:07:16Z",
+	} {
+		var f quickfix.FIXUTCTimestamp
+		if err := f.Read([]byte(timeStr)); err == nil {
+			t.Errorf("Expected error for %v", timeStr)
+		}
+	}
+}
+
+func TestFIXUTCTimestampPicosRoundTrip(t *testing.T) {
+	var tests = []struct {
+		timeStr           string
+		expectedPicos     int
+		expectedTruncated bool
+	}{
+		{"20160208-22:07:16.954123123456", 456, true},
+		{"20160208-22:07:16.954123123400", 400, true},
+		{"20160208-22:07:16.9541231234", 400, true},
+		{"20160208-22:07:16.954123123000", 0, false},
+		{"20160208-22:07:16.9541231230", 0, false},
+	}
+
+	for _, test := range tests {
+		var f quickfix.FIXUTCTimestamp
+		if err := f.Read([]byte(test.timeStr)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if f.Picoseconds != test.expectedPicos {
+			t.Errorf("For Picoseconds expected %v got %v", test.expectedPicos, f.Picoseconds)
+		}
+
+		if f.Truncated() != test.expectedTruncated {
+			t.Errorf("For Truncated expected %v got %v", test.expectedTruncated, f.Truncated())
+		}
+
+		if b := f.Write(); string(b) != test.timeStr {
+			t.Errorf("got %s; want %s", b, test.timeStr)
+		}
+	}
+
+	var f quickfix.FIXUTCTimestamp
+	if err := f.Read([]byte("20160208-22:07:16.954123123456")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if b := f.Write(); string(b) != "20160208-22:07:16.954123123456" {
+		t.Errorf("got %s; want %s", b, "20160208-22:07:16.954123123456")
+	}
+}
+
+func TestFIXUTCTimestampReadWritePreservesPrecision(t *testing.T) {
+	for _, timeStr := range []string{
+		"20160208-22:07:16",
+		"20160208-22:07:16.1",
+		"20160208-22:07:16.100",
+		"20160208-22:07:16.95412",
+		"20160208-22:07:16.954120",
+		"20160208-22:07:16.954123120",
+		"20160208-22:07:16.1234567",
+		"20160208-22:07:16.954123123450",
+		"20160208-22:07:16.95412312345",
+	} {
+		var f quickfix.FIXUTCTimestamp
+		if err := f.Read([]byte(timeStr)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+
+		if b := f.Write(); string(b) != timeStr {
+			t.Errorf("got %s; want %s", b, timeStr)
+		}
+	}
+}
+
+func TestFIXUTCTimestampWriteDigitsOutsidePrecision(t *testing.T) {
+	var f quickfix.FIXUTCTimestamp
+	if err := f.Read([]byte("20160208-22:07:16.95412")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	f.Precision = quickfix.Millis
+	if b := f.Write(); string(b) != "20160208-22:07:16.954" {
+		t.Errorf("got %s; want %s", b, "20160208-22:07:16.954")
+	}
+}
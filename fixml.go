@@ -0,0 +1,521 @@
+package quickfix
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+//fixmlHeader is the FIXML element name of the standard header, unless the header of the DataDictionary is abbreviated
+const fixmlHeader = "Hdr"
+
+//MarshalMessageFIXML encodes msg as a FIXML document. Messages, components and repeating groups are written as
+//elements and fields as attributes, named after their abbreviations in the transport and application
+//DataDictionary, or their names if no abbreviation is defined. BeginString, BodyLength, MsgType, ApplVerID and the
+//trailer are implied by the document and are not written. Timestamps and dates are written in the XML Schema format.
+func MarshalMessageFIXML(
+	msg *Message,
+	transportDataDictionary *datadictionary.DataDictionary,
+	applicationDataDictionary *datadictionary.DataDictionary,
+) ([]byte, error) {
+	c, err := newFIXMLCodec(transportDataDictionary, applicationDataDictionary)
+	if err != nil {
+		return nil, err
+	}
+
+	msgType, err := msg.Header.GetBytes(tagMsgType)
+	if err != nil {
+		return nil, err
+	}
+
+	messageDef, ok := c.appDict.Messages[string(msgType)]
+	if !ok {
+		return nil, fmt.Errorf("message type %s is not defined in the DataDictionary", msgType)
+	}
+
+	header := &fixmlElement{name: c.headerName()}
+	if err := c.encodeSection(header, c.transportDict.Header.Parts, &msg.Header.FieldMap); err != nil {
+		return nil, err
+	}
+
+	message := &fixmlElement{name: messageDef.Abbreviation(), children: []*fixmlElement{header}}
+	if err := c.encodeSection(message, messageDef.Parts, &msg.Body.FieldMap); err != nil {
+		return nil, err
+	}
+
+	root := &fixmlElement{
+		name: "FIXML",
+		attrs: []xml.Attr{
+			{Name: xml.Name{Local: "xmlns"}, Value: c.namespace()},
+			{Name: xml.Name{Local: "v"}, Value: c.version()},
+		},
+		children: []*fixmlElement{message},
+	}
+
+	return root.appendTo(nil), nil
+}
+
+//UnmarshalMessageFIXML decodes a FIXML document holding a single message into msg, using the abbreviations of the
+//transport and application DataDictionary.
+func UnmarshalMessageFIXML(
+	msg *Message,
+	data []byte,
+	transportDataDictionary *datadictionary.DataDictionary,
+	applicationDataDictionary *datadictionary.DataDictionary,
+) error {
+	c, err := newFIXMLCodec(transportDataDictionary, applicationDataDictionary)
+	if err != nil {
+		return err
+	}
+
+	root, err := parseFIXML(data)
+	if err != nil {
+		return err
+	}
+
+	if root.name != "FIXML" {
+		return fmt.Errorf("unexpected root element %v, expected FIXML", root.name)
+	}
+
+	if v, ok := root.attr("v"); ok && v != c.version() {
+		return fmt.Errorf("FIXML version %v does not match DataDictionary version %v", v, c.version())
+	}
+
+	if len(root.children) != 1 {
+		return fmt.Errorf("expected a single message in FIXML document, found %v", len(root.children))
+	}
+
+	message := root.children[0]
+	var messageDef *datadictionary.MessageDef
+	for _, def := range c.appDict.Messages {
+		if def.Abbreviation() == message.name {
+			messageDef = def
+			break
+		}
+	}
+
+	if messageDef == nil {
+		return fmt.Errorf("unknown FIXML message %v", message.name)
+	}
+
+	msg.Header.Clear()
+	msg.Body.Clear()
+	msg.Trailer.Clear()
+	msg.rawMessage = nil
+	msg.bodyBytes = nil
+
+	body := &fixmlElement{name: message.name, attrs: message.attrs}
+	for _, child := range message.children {
+		if child.name != c.headerName() {
+			body.children = append(body.children, child)
+			continue
+		}
+
+		if err := c.decodeParts(child, c.transportDict.Header.Parts, &msg.Header.FieldMap); err != nil {
+			return err
+		}
+	}
+
+	if c.appDict.Major >= 5 {
+		msg.Header.SetString(tagBeginString, BeginStringFIXT11)
+		msg.Header.SetString(tagApplVerID, applVerIDLookup[c.beginString()])
+	} else {
+		msg.Header.SetString(tagBeginString, c.beginString())
+	}
+	msg.Header.SetString(tagMsgType, messageDef.MsgType)
+
+	return c.decodeParts(body, messageDef.Parts, &msg.Body.FieldMap)
+}
+
+type fixmlCodec struct {
+	transportDict *datadictionary.DataDictionary
+	appDict       *datadictionary.DataDictionary
+}
+
+func newFIXMLCodec(transportDataDictionary, applicationDataDictionary *datadictionary.DataDictionary) (fixmlCodec, error) {
+	dicts := newDataDictionaries(transportDataDictionary, applicationDataDictionary)
+	if dicts[0] == nil {
+		return fixmlCodec{}, errors.New("FIXML requires a DataDictionary")
+	}
+
+	if dicts[0].Header == nil {
+		return fixmlCodec{}, errors.New("FIXML requires a transport DataDictionary with a header")
+	}
+
+	return fixmlCodec{transportDict: dicts[0], appDict: dicts[1]}, nil
+}
+
+func (c fixmlCodec) headerName() string {
+	//the header is unnamed, its abbreviation is empty unless defined
+	if name := c.transportDict.Header.Abbreviation(); name != "" {
+		return name
+	}
+
+	return fixmlHeader
+}
+
+//beginString returns the FIX version of the application DataDictionary, e.g. FIX.4.4 or FIX.5.0SP2
+func (c fixmlCodec) beginString() string {
+	beginString := fmt.Sprintf("FIX.%v.%v", c.appDict.Major, c.appDict.Minor)
+	if c.appDict.ServicePack > 0 {
+		beginString += fmt.Sprintf("SP%v", c.appDict.ServicePack)
+	}
+
+	return beginString
+}
+
+//version returns the value of the FIXML v attribute, e.g. 4.4 or 5.0 SP2
+func (c fixmlCodec) version() string {
+	version := fmt.Sprintf("%v.%v", c.appDict.Major, c.appDict.Minor)
+	if c.appDict.ServicePack > 0 {
+		version += fmt.Sprintf(" SP%v", c.appDict.ServicePack)
+	}
+
+	return version
+}
+
+func (c fixmlCodec) namespace() string {
+	namespace := fmt.Sprintf("http://www.fixprotocol.org/FIXML-%v-%v", c.appDict.Major, c.appDict.Minor)
+	if c.appDict.ServicePack > 0 {
+		namespace += fmt.Sprintf("-SP%v", c.appDict.ServicePack)
+	}
+
+	return namespace
+}
+
+//isImplied returns true for header fields implied by the FIXML document
+func isImplied(tag Tag) bool {
+	switch tag {
+	case tagBeginString, tagBodyLength, tagMsgType, tagApplVerID:
+		return true
+	}
+
+	return false
+}
+
+//encodeSection encodes the header or body m into e, failing if m holds fields not defined in parts
+func (c fixmlCodec) encodeSection(e *fixmlElement, parts []datadictionary.MessagePart, m *FieldMap) error {
+	encoded := make(map[Tag]bool)
+	if err := c.encodeParts(e, parts, m, encoded); err != nil {
+		return err
+	}
+
+	for _, tag := range m.sortedTags() {
+		if !encoded[tag] && !isImplied(tag) {
+			return fmt.Errorf("tag %v is not defined for FIXML element %v", tag, e.name)
+		}
+	}
+
+	return nil
+}
+
+func (c fixmlCodec) encodeParts(e *fixmlElement, parts []datadictionary.MessagePart, m *FieldMap, encoded map[Tag]bool) error {
+	for _, part := range parts {
+		switch part := part.(type) {
+		case *datadictionary.FieldDef:
+			tag := Tag(part.Tag())
+			if part.IsGroup() {
+				if err := c.encodeGroup(e, part, part.Abbreviation(), m, encoded); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if isImplied(tag) || !m.Has(tag) {
+				continue
+			}
+
+			value, err := m.GetBytes(tag)
+			if err != nil {
+				return err
+			}
+
+			e.attrs = append(e.attrs, xml.Attr{Name: xml.Name{Local: part.Abbreviation()}, Value: toFIXMLValue(part.Type, value)})
+			encoded[tag] = true
+
+		case datadictionary.Component:
+			if group := wrappedGroup(part); group != nil {
+				if err := c.encodeGroup(e, group, group.Abbreviation(), m, encoded); err != nil {
+					return err
+				}
+				continue
+			}
+
+			child := &fixmlElement{name: part.Abbreviation()}
+			if err := c.encodeParts(child, part.Parts(), m, encoded); err != nil {
+				return err
+			}
+
+			if len(child.attrs) > 0 || len(child.children) > 0 {
+				e.children = append(e.children, child)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c fixmlCodec) encodeGroup(e *fixmlElement, groupDef *datadictionary.FieldDef, name string, m *FieldMap, encoded map[Tag]bool) error {
+	tag := Tag(groupDef.Tag())
+	if !m.Has(tag) {
+		return nil
+	}
+
+	rg := NewRepeatingGroup(tag, groupTemplate(groupDef))
+	if err := m.GetGroup(rg); err != nil {
+		return err
+	}
+
+	for i := 0; i < rg.Len(); i++ {
+		child := &fixmlElement{name: name}
+		if err := c.encodeParts(child, groupDef.Parts, &rg.Get(i).FieldMap, make(map[Tag]bool)); err != nil {
+			return err
+		}
+		e.children = append(e.children, child)
+	}
+
+	encoded[tag] = true
+	return nil
+}
+
+func (c fixmlCodec) decodeParts(e *fixmlElement, parts []datadictionary.MessagePart, m *FieldMap) error {
+	fields := make(map[string]*datadictionary.FieldDef)
+	groups := make(map[string]*datadictionary.FieldDef)
+	components := make(map[string]datadictionary.Component)
+	for _, part := range parts {
+		switch part := part.(type) {
+		case *datadictionary.FieldDef:
+			if part.IsGroup() {
+				groups[part.Abbreviation()] = part
+			} else {
+				fields[part.Abbreviation()] = part
+			}
+
+		case datadictionary.Component:
+			if group := wrappedGroup(part); group != nil {
+				groups[group.Abbreviation()] = group
+			} else {
+				components[part.Abbreviation()] = part
+			}
+		}
+	}
+
+	for _, attr := range e.attrs {
+		fieldDef, ok := fields[attr.Name.Local]
+		if !ok {
+			return fmt.Errorf("unknown attribute %v of FIXML element %v", attr.Name.Local, e.name)
+		}
+
+		value, err := fromFIXMLValue(fieldDef.Type, attr.Value)
+		if err != nil {
+			return fmt.Errorf("attribute %v of FIXML element %v: %v", attr.Name.Local, e.name, err)
+		}
+		m.SetString(Tag(fieldDef.Tag()), value)
+	}
+
+	var repeatingGroups []*RepeatingGroup
+	groupByName := make(map[string]*RepeatingGroup)
+	for _, child := range e.children {
+		if component, ok := components[child.name]; ok {
+			if err := c.decodeParts(child, component.Parts(), m); err != nil {
+				return err
+			}
+			continue
+		}
+
+		groupDef, ok := groups[child.name]
+		if !ok {
+			return fmt.Errorf("unknown FIXML element %v in %v", child.name, e.name)
+		}
+
+		rg, ok := groupByName[child.name]
+		if !ok {
+			rg = NewRepeatingGroup(Tag(groupDef.Tag()), groupTemplate(groupDef))
+			groupByName[child.name] = rg
+			repeatingGroups = append(repeatingGroups, rg)
+		}
+
+		if err := c.decodeParts(child, groupDef.Parts, &rg.Add().FieldMap); err != nil {
+			return err
+		}
+	}
+
+	for _, rg := range repeatingGroups {
+		m.SetGroup(rg)
+	}
+
+	return nil
+}
+
+//wrappedGroup returns the repeating group of a component holding nothing but the group
+func wrappedGroup(component datadictionary.Component) *datadictionary.FieldDef {
+	parts := component.Parts()
+	if len(parts) != 1 {
+		return nil
+	}
+
+	if fieldDef, ok := parts[0].(*datadictionary.FieldDef); ok && fieldDef.IsGroup() {
+		return fieldDef
+	}
+
+	return nil
+}
+
+//toFIXMLValue converts timestamps and dates to their XML Schema format, other values are unchanged
+func toFIXMLValue(fixType string, value []byte) string {
+	switch fixType {
+	case "UTCTIMESTAMP", "TZTIMESTAMP":
+		if len(value) > 9 && value[8] == '-' {
+			return fmt.Sprintf("%s-%s-%sT%s", value[0:4], value[4:6], value[6:8], value[9:])
+		}
+
+	case "UTCDATEONLY", "UTCDATE", "LOCALMKTDATE":
+		if len(value) == 8 {
+			return fmt.Sprintf("%s-%s-%s", value[0:4], value[4:6], value[6:8])
+		}
+	}
+
+	return string(value)
+}
+
+//fromFIXMLValue converts XML Schema timestamps and dates to their FIX format, other values are unchanged. UTC
+//timestamps with a time zone offset are converted to UTC.
+func fromFIXMLValue(fixType string, value string) (string, error) {
+	switch fixType {
+	case "UTCTIMESTAMP":
+		if len(value) < len("2006-01-02T15:04:05") || value[10] != 'T' {
+			break
+		}
+
+		if !strings.ContainsAny(value[19:], "Z+-") {
+			return fromFIXMLValue("TZTIMESTAMP", value)
+		}
+
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return "", err
+		}
+
+		var digits int
+		if fraction := strings.IndexByte(value, '.'); fraction >= 0 {
+			for digits < len(value)-fraction-1 && isDecimal(value[fraction+1+digits]) {
+				digits++
+			}
+		}
+
+		if digits > 9 {
+			digits = 9
+		}
+
+		return string(appendTime(nil, t.UTC(), utcTimestampSecondsFormat, fractionPrecision(digits), 0, 0)), nil
+
+	case "TZTIMESTAMP":
+		if len(value) > len("2006-01-02T") && value[10] == 'T' {
+			return strings.Replace(value[0:10], "-", "", -1) + "-" + value[11:], nil
+		}
+
+	case "UTCDATEONLY", "UTCDATE", "LOCALMKTDATE":
+		if len(value) == len("2006-01-02") && value[4] == '-' && value[7] == '-' {
+			return strings.Replace(value, "-", "", -1), nil
+		}
+	}
+
+	return value, nil
+}
+
+//fixmlElement is an element of a FIXML document
+type fixmlElement struct {
+	name     string
+	attrs    []xml.Attr
+	children []*fixmlElement
+}
+
+func (e *fixmlElement) attr(name string) (string, bool) {
+	for _, attr := range e.attrs {
+		if attr.Name.Local == name {
+			return attr.Value, true
+		}
+	}
+
+	return "", false
+}
+
+func (e *fixmlElement) appendTo(b []byte) []byte {
+	b = append(b, '<')
+	b = append(b, e.name...)
+	for _, attr := range e.attrs {
+		b = append(b, ' ')
+		b = append(b, attr.Name.Local...)
+		b = append(b, `="`...)
+		b = appendEscapedXML(b, attr.Value)
+		b = append(b, '"')
+	}
+
+	if len(e.children) == 0 {
+		return append(b, "/>"...)
+	}
+
+	b = append(b, '>')
+	for _, child := range e.children {
+		b = child.appendTo(b)
+	}
+
+	b = append(b, "</"...)
+	b = append(b, e.name...)
+	return append(b, '>')
+}
+
+func appendEscapedXML(b []byte, s string) []byte {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(s))
+	return append(b, buffer.Bytes()...)
+}
+
+//parseFIXML returns the root element of a FIXML document. Namespace declarations and text are ignored.
+func parseFIXML(data []byte) (*fixmlElement, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root *fixmlElement
+	var stack []*fixmlElement
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch token := token.(type) {
+		case xml.StartElement:
+			e := &fixmlElement{name: token.Name.Local}
+			for _, attr := range token.Attr {
+				if attr.Name.Space == "" && attr.Name.Local != "xmlns" {
+					e.attrs = append(e.attrs, attr)
+				}
+			}
+
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, e)
+			} else if root == nil {
+				root = e
+			}
+			stack = append(stack, e)
+
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	if root == nil {
+		return nil, errors.New("empty FIXML document")
+	}
+
+	return root, nil
+}
@@ -0,0 +1,191 @@
+package quickfix
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	fixmlFIX44Order = `<FIXML xmlns="http://www.fixprotocol.org/FIXML-4-4" v="4.4">` +
+		`<Order ID="123456" Acct="26522154" Side="2" TxnTm="2001-09-11T09:30:47" Typ="2" Px="93.25" TmInForce="0">` +
+		`<Hdr SID="AFUNDMGR" TID="ABROKER" SeqNum="521" PosDup="N" PosRsnd="N" Snt="2001-09-11T09:30:47.123"/>` +
+		`<Pty ID="FIRM1" Src="D" R="1"><Sub ID="DESK" Typ="4"/></Pty>` +
+		`<Pty ID="TRADER" Src="D" R="11"/>` +
+		`<Instrmt Sym="IBM" ID="459200101" Src="1"/>` +
+		`<OrdQty Qty="1000"/>` +
+		`</Order></FIXML>`
+
+	fixmlFIX44ExecRpt = `<FIXML xmlns="http://www.fixprotocol.org/FIXML-4-4" v="4.4">` +
+		`<ExecRpt OrdID="O1" ID="123456" ExecID="E1" ExecTyp="F" Stat="2" Side="2" Typ="2" Px="93.25" LastQty="1000" LastPx="93.25" TrdDt="2001-09-11" TxnTm="2001-09-11T09:30:48.001" LeavesQty="0" CumQty="1000" AvgPx="93.25" Txt="Filled &amp; booked">` +
+		`<Hdr SID="ABROKER" TID="AFUNDMGR" SeqNum="522" Snt="2001-09-11T09:30:48.001"/>` +
+		`<Instrmt Sym="IBM" MMY="200112" MatDt="2001-12-21"/>` +
+		`<OrdQty Qty="1000"/>` +
+		`</ExecRpt></FIXML>`
+
+	fixmlFIX44MktDataReq = `<FIXML xmlns="http://www.fixprotocol.org/FIXML-4-4" v="4.4">` +
+		`<MktDataReq ReqID="MD1" SubReqTyp="1" MktDepth="1">` +
+		`<Hdr SID="AFUNDMGR" TID="ABROKER" SeqNum="3" Snt="2001-09-11T09:30:47"/>` +
+		`<Req Typ="0"/><Req Typ="1"/>` +
+		`<InstReq><Instrmt Sym="IBM"/></InstReq>` +
+		`<InstReq><Instrmt Sym="MSFT"/></InstReq>` +
+		`</MktDataReq></FIXML>`
+
+	fixmlFIX50SP2ExecRpt = `<FIXML xmlns="http://www.fixprotocol.org/FIXML-5-0-SP2" v="5.0 SP2">` +
+		`<ExecRpt OrdID="O1" ID="123456" ExecID="E1" ExecTyp="F" Stat="2" Acct="26522154" Side="2" LastQty="1000" LastPx="93.25" TxnTm="2001-09-11T09:30:48.001" LeavesQty="0" CumQty="1000" AvgPx="93.25">` +
+		`<Hdr SID="ABROKER" TID="AFUNDMGR" SeqNum="522" Snt="2001-09-11T09:30:48.001"/>` +
+		`<Pty ID="FIRM1" Src="D" R="1"><Sub ID="DESK" Typ="4"/><Sub ID="BOOK" Typ="5"/></Pty>` +
+		`<Instrmt Sym="IBM" ID="459200101" Src="1"><AID AltID="US4592001014" AltIDSrc="4"/></Instrmt>` +
+		`<OrdQty Qty="1000"/>` +
+		`</ExecRpt></FIXML>`
+
+	fixmlFIX50SP2MktDataReq = `<FIXML xmlns="http://www.fixprotocol.org/FIXML-5-0-SP2" v="5.0 SP2">` +
+		`<MktDataReq ReqID="MD1" SubReqTyp="1" MktDepth="1">` +
+		`<Hdr SID="AFUNDMGR" TID="ABROKER" SeqNum="3" Snt="2001-09-11T09:30:47"/>` +
+		`<Req Typ="0"/><Req Typ="1"/>` +
+		`<InstReq><Instrmt Sym="IBM"/></InstReq>` +
+		`</MktDataReq></FIXML>`
+)
+
+type FIXMLSuite struct {
+	suite.Suite
+	fix44, fixt11, fix50sp2 *datadictionary.DataDictionary
+}
+
+func TestFIXMLSuite(t *testing.T) {
+	suite.Run(t, new(FIXMLSuite))
+}
+
+func (s *FIXMLSuite) SetupSuite() {
+	var err error
+	s.fix44, err = datadictionary.Parse("_test_data/fixml/FIX44.xml")
+	s.Require().Nil(err)
+	s.fixt11, err = datadictionary.Parse("_test_data/fixml/FIXT11.xml")
+	s.Require().Nil(err)
+	s.fix50sp2, err = datadictionary.Parse("_test_data/fixml/FIX50SP2.xml")
+	s.Require().Nil(err)
+}
+
+func (s *FIXMLSuite) TestRoundTrip() {
+	var tests = []struct {
+		fixml                  string
+		transportDict, appDict *datadictionary.DataDictionary
+	}{
+		{fixmlFIX44Order, s.fix44, s.fix44},
+		{fixmlFIX44ExecRpt, s.fix44, s.fix44},
+		{fixmlFIX44MktDataReq, s.fix44, s.fix44},
+		{fixmlFIX50SP2ExecRpt, s.fixt11, s.fix50sp2},
+		{fixmlFIX50SP2MktDataReq, s.fixt11, s.fix50sp2},
+	}
+
+	for _, test := range tests {
+		msg := NewMessage()
+		s.Require().Nil(UnmarshalMessageFIXML(msg, []byte(test.fixml), test.transportDict, test.appDict))
+
+		b, err := MarshalMessageFIXML(msg, test.transportDict, test.appDict)
+		s.Require().Nil(err)
+		s.Equal(test.fixml, string(b))
+
+		parsed := NewMessage()
+		s.Require().Nil(ParseMessageWithDataDictionary(parsed, bytes.NewBuffer(msg.build()), test.transportDict, test.appDict))
+
+		b, err = MarshalMessageFIXML(parsed, test.transportDict, test.appDict)
+		s.Require().Nil(err)
+		s.Equal(test.fixml, string(b), "FIXML of tag=value message %v", parsed)
+	}
+}
+
+func (s *FIXMLSuite) TestUnmarshalFIX44() {
+	msg := NewMessage()
+	s.Require().Nil(UnmarshalMessageFIXML(msg, []byte(fixmlFIX44Order), s.fix44, s.fix44))
+
+	s.Equal("8=FIX.4.4|9=242|35=D|34=521|43=N|49=AFUNDMGR|52=20010911-09:30:47.123|56=ABROKER|97=N|"+
+		"1=26522154|11=123456|22=1|38=1000|40=2|44=93.25|48=459200101|54=2|55=IBM|59=0|60=20010911-09:30:47|"+
+		"453=2|448=FIRM1|447=D|452=1|802=1|523=DESK|803=4|448=TRADER|447=D|452=11|10=142|", msg.String())
+}
+
+func (s *FIXMLSuite) TestUnmarshalFIX50SP2Routes() {
+	msg := NewMessage()
+	s.Require().Nil(UnmarshalMessageFIXML(msg, []byte(fixmlFIX50SP2ExecRpt), s.fixt11, s.fix50sp2))
+
+	var routed bool
+	router := NewMessageRouter()
+	router.AddRoute(BeginStringFIX50, "8", func(msg *Message, sessionID SessionID) MessageRejectError {
+		routed = true
+		return nil
+	})
+
+	s.Nil(router.Route(msg, SessionID{}))
+	s.True(routed)
+
+	beginString, _ := msg.Header.GetString(tagBeginString)
+	s.Equal(BeginStringFIXT11, beginString)
+	applVerID, _ := msg.Header.GetString(tagApplVerID)
+	s.Equal(ApplVerIDFIX50SP2, applVerID)
+}
+
+func (s *FIXMLSuite) TestUnmarshalFormattedDocument() {
+	msg := NewMessage()
+	s.Require().Nil(UnmarshalMessageFIXML(msg, []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<FIXML xmlns="http://www.fixprotocol.org/FIXML-4-4" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" v="4.4">
+	<Order ID="123456" Side="2" TxnTm="2001-09-11T09:30:47.5-05:00" Typ="1">
+		<Hdr SID="AFUNDMGR" TID="ABROKER" SeqNum="521" Snt="2001-09-11T14:30:47Z"/>
+		<Instrmt Sym="IBM"/>
+		<OrdQty Qty="1000"/>
+	</Order>
+</FIXML>`), s.fix44, s.fix44))
+
+	sendingTime, _ := msg.Header.GetString(tagSendingTime)
+	s.Equal("20010911-14:30:47", sendingTime)
+	transactTime, _ := msg.Body.GetString(Tag(60))
+	s.Equal("20010911-14:30:47.500", transactTime)
+}
+
+func (s *FIXMLSuite) TestUnmarshalErrors() {
+	var tests = []string{
+		`<Order ID="1"/>`,
+		`<FIXML v="5.0 SP2"><Order ID="1"/></FIXML>`,
+		`<FIXML v="4.4"><Unknown ID="1"/></FIXML>`,
+		`<FIXML v="4.4"><Order ID="1" Unknown="1"/></FIXML>`,
+		`<FIXML v="4.4"><Order ID="1"><Unknown/></Order></FIXML>`,
+		`<FIXML v="4.4"><Order ID="1"/><Order ID="2"/></FIXML>`,
+		`<FIXML v="4.4"><Order ID="1">`,
+	}
+
+	for _, test := range tests {
+		s.NotNil(UnmarshalMessageFIXML(NewMessage(), []byte(test), s.fix44, s.fix44), test)
+	}
+}
+
+func (s *FIXMLSuite) TestMarshalUndefinedField() {
+	msg := NewMessage()
+	s.Require().Nil(UnmarshalMessageFIXML(msg, []byte(fixmlFIX44Order), s.fix44, s.fix44))
+	msg.Body.SetString(Tag(5000), "custom")
+
+	_, err := MarshalMessageFIXML(msg, s.fix44, s.fix44)
+	s.NotNil(err)
+}
+
+func (s *FIXMLSuite) TestMarshalWithoutAbbreviations() {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	s.Require().Nil(err)
+
+	msg := NewMessage()
+	s.Require().Nil(UnmarshalMessageFIXML(msg, []byte(fixmlFIX44MktDataReq), s.fix44, s.fix44))
+
+	b, err := MarshalMessageFIXML(msg, dict, dict)
+	s.Require().Nil(err)
+	s.Equal(`<FIXML xmlns="http://www.fixprotocol.org/FIXML-4-4" v="4.4">`+
+		`<MarketDataRequest MDReqID="MD1" SubscriptionRequestType="1" MarketDepth="1">`+
+		`<Hdr SenderCompID="AFUNDMGR" TargetCompID="ABROKER" MsgSeqNum="3" SendingTime="2001-09-11T09:30:47"/>`+
+		`<NoMDEntryTypes MDEntryType="0"/><NoMDEntryTypes MDEntryType="1"/>`+
+		`<NoRelatedSym><Instrument Symbol="IBM"/></NoRelatedSym>`+
+		`<NoRelatedSym><Instrument Symbol="MSFT"/></NoRelatedSym>`+
+		`</MarketDataRequest></FIXML>`, string(b))
+
+	decoded := NewMessage()
+	s.Require().Nil(UnmarshalMessageFIXML(decoded, b, dict, dict))
+	s.Equal(msg.String(), decoded.String())
+}
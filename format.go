@@ -0,0 +1,144 @@
+package quickfix
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+//FormatOptions controls how FormatMessage renders a message
+type FormatOptions struct {
+	//TransportDataDictionary names the header and trailer fields of FIXT messages. If nil, the DataDictionary passed
+	//to FormatMessage is used for all sections.
+	TransportDataDictionary *datadictionary.DataDictionary
+
+	//Indent is written once per level of nesting, defaults to two spaces
+	Indent string
+
+	//Color highlights the header, body and trailer with ANSI escape codes
+	Color bool
+}
+
+const (
+	ansiReset = "\x1b[0m"
+	ansiBold  = "\x1b[1m"
+)
+
+var sectionColors = map[string]string{
+	"Header":  "\x1b[36m",
+	"Body":    "\x1b[32m",
+	"Trailer": "\x1b[35m",
+}
+
+//FormatMessage renders msg for humans, one field per line under Header, Body and Trailer headings. Each line holds
+//the tag, the field name and the value followed by the description of enumerated values, as defined in the optional
+//DataDictionary. Parsed messages are rendered in the order fields were received. Repeating groups defined by the
+//DataDictionary are numbered and indented beneath their NumInGroup field, without a DataDictionary group fields are
+//listed as received.
+func FormatMessage(msg *Message, dd *datadictionary.DataDictionary, opts FormatOptions) string {
+	if opts.Indent == "" {
+		opts.Indent = "  "
+	}
+
+	f := messageFormatter{
+		dicts:   newDataDictionaries(opts.TransportDataDictionary, dd),
+		options: opts,
+	}
+
+	msgType, _ := msg.Header.GetBytes(tagMsgType)
+	headerDef, bodyDef, trailerDef := f.dicts.messageDefs(string(msgType))
+
+	header, body, trailer := f.dicts.messageFields(msg)
+	f.writeSection("Header", header, headerDef)
+	f.writeSection("Body", body, bodyDef)
+	f.writeSection("Trailer", trailer, trailerDef)
+
+	return f.String()
+}
+
+type messageFormatter struct {
+	strings.Builder
+	dicts   dataDictionaries
+	options FormatOptions
+	color   string
+}
+
+func (f *messageFormatter) writeSection(name string, tvs []TagValue, defs fieldDefs) {
+	if f.options.Color {
+		f.color = sectionColors[name]
+		f.WriteString(ansiBold + f.color + name + ansiReset + "\n")
+	} else {
+		f.WriteString(name + "\n")
+	}
+
+	f.writeFields(tvs, defs, nil, 1)
+}
+
+//writeFields writes the fields of tvs at the given depth. Within a group, fields are written up to the next instance
+//of the group or the first field not part of the group, returning the fields remaining.
+func (f *messageFormatter) writeFields(tvs []TagValue, defs fieldDefs, groupDef *datadictionary.FieldDef, depth int) []TagValue {
+	for first := true; len(tvs) > 0; first = false {
+		tv := tvs[0]
+		fieldDef := defs(tv.tag)
+		if groupDef != nil && (fieldDef == nil || (!first && tv.tag == Tag(groupDef.Fields[0].Tag()))) {
+			break
+		}
+
+		fieldType := f.dicts.fieldType(tv.tag)
+		if fieldDef != nil {
+			fieldType = fieldDef.FieldType
+		}
+
+		f.writeField(tv, fieldType, depth)
+		tvs = tvs[1:]
+
+		if fieldDef == nil || !fieldDef.IsGroup() || len(fieldDef.Fields) == 0 {
+			continue
+		}
+
+		count, err := atoi(tv.value)
+		if err != nil {
+			continue
+		}
+
+		for i := 1; i <= count && len(tvs) > 0 && tvs[0].tag == Tag(fieldDef.Fields[0].Tag()); i++ {
+			f.writeIndent(depth + 1)
+			f.WriteString("[" + strconv.Itoa(i) + "]\n")
+			tvs = f.writeFields(tvs, groupDefFields(fieldDef), fieldDef, depth+2)
+		}
+	}
+
+	return tvs
+}
+
+func (f *messageFormatter) writeField(tv TagValue, fieldType *datadictionary.FieldType, depth int) {
+	f.writeIndent(depth)
+	f.WriteString(strconv.Itoa(int(tv.tag)))
+
+	if fieldType != nil {
+		f.WriteByte(' ')
+		if f.options.Color {
+			f.WriteString(f.color + fieldType.Name() + ansiReset)
+		} else {
+			f.WriteString(fieldType.Name())
+		}
+	}
+
+	f.WriteString(" = ")
+	f.Write(tv.value)
+
+	if fieldType != nil {
+		if enum, ok := fieldType.Enums[string(tv.value)]; ok && enum.Description != "" {
+			f.WriteString(" (" + enum.Description + ")")
+		}
+	}
+
+	f.WriteByte('\n')
+}
+
+func (f *messageFormatter) writeIndent(depth int) {
+	for i := 0; i < depth; i++ {
+		f.WriteString(f.options.Indent)
+	}
+}
@@ -0,0 +1,139 @@
+package quickfix
+
+import (
+	"testing"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/stretchr/testify/suite"
+)
+
+type FormatSuite struct {
+	suite.Suite
+	dict *datadictionary.DataDictionary
+}
+
+func TestFormatSuite(t *testing.T) {
+	suite.Run(t, new(FormatSuite))
+}
+
+func (s *FormatSuite) SetupSuite() {
+	var err error
+	s.dict, err = datadictionary.Parse("spec/FIX44.xml")
+	s.Require().Nil(err)
+}
+
+func (s *FormatSuite) parse(rawMsg string) *Message {
+	msg := NewMessage()
+	s.Require().Nil(ParseMessageWithDataDictionary(msg, newBuffer(rawMsg), s.dict, s.dict))
+	return msg
+}
+
+func (s *FormatSuite) TestFormatMessage() {
+	msg := s.parse("8=FIX.4.4|9=0|35=D|34=2|49=TW|56=ISLD|11=ID|38=100|54=1|453=2|448=P1|447=D|452=1|802=1|523=S1|803=1|448=P2|447=D|452=3|10=000|")
+
+	s.Equal(`Header
+  8 BeginString = FIX.4.4
+  9 BodyLength = 105
+  35 MsgType = D (NEWORDERSINGLE)
+  34 MsgSeqNum = 2
+  49 SenderCompID = TW
+  56 TargetCompID = ISLD
+Body
+  11 ClOrdID = ID
+  38 OrderQty = 100
+  54 Side = 1 (BUY)
+  453 NoPartyIDs = 2
+    [1]
+      448 PartyID = P1
+      447 PartyIDSource = D (PROPCODE)
+      452 PartyRole = 1 (EXECUTINGFIRM)
+      802 NoPartySubIDs = 1
+        [1]
+          523 PartySubID = S1
+          803 PartySubIDType = 1 (FIRM)
+    [2]
+      448 PartyID = P2
+      447 PartyIDSource = D (PROPCODE)
+      452 PartyRole = 3 (CLIENTID)
+Trailer
+  10 CheckSum = 235
+`, FormatMessage(msg, s.dict, FormatOptions{}))
+}
+
+func (s *FormatSuite) TestFormatMessageWithoutDataDictionary() {
+	msg := s.parse("8=FIX.4.4|9=0|35=D|11=ID|5000=custom|10=000|")
+
+	s.Equal(`Header
+ 8 = FIX.4.4
+ 9 = 23
+ 35 = D
+Body
+ 11 = ID
+ 5000 = custom
+Trailer
+ 10 = 178
+`, FormatMessage(msg, nil, FormatOptions{Indent: " "}))
+}
+
+func (s *FormatSuite) TestFormatMessageColor() {
+	msg := s.parse("8=FIX.4.4|9=0|35=0|10=000|")
+
+	s.Equal("\x1b[1m\x1b[36mHeader\x1b[0m\n"+
+		"  8 \x1b[36mBeginString\x1b[0m = FIX.4.4\n"+
+		"  9 \x1b[36mBodyLength\x1b[0m = 5\n"+
+		"  35 \x1b[36mMsgType\x1b[0m = 0 (HEARTBEAT)\n"+
+		"\x1b[1m\x1b[32mBody\x1b[0m\n"+
+		"\x1b[1m\x1b[35mTrailer\x1b[0m\n"+
+		"  10 \x1b[35mCheckSum\x1b[0m = 163\n",
+		FormatMessage(msg, s.dict, FormatOptions{Color: true}))
+}
+
+func (s *FormatSuite) TestFormatMessageGroupWithoutDataDictionary() {
+	msg := NewMessage()
+	s.Require().Nil(ParseMessage(msg, newBuffer("8=FIX.4.4|9=0|35=D|11=ID|453=2|448=P1|447=D|452=1|448=P2|447=D|452=3|10=000|")))
+
+	s.Equal(`Header
+  8 = FIX.4.4
+  9 = 55
+  35 = D
+Body
+  11 = ID
+  453 = 2
+  448 = P1
+  447 = D
+  452 = 1
+  448 = P2
+  447 = D
+  452 = 3
+Trailer
+  10 = 060
+`, FormatMessage(msg, nil, FormatOptions{}))
+}
+
+func (s *FormatSuite) TestFormatBuiltMessage() {
+	msg := NewMessage()
+	msg.Header.SetString(tagBeginString, "FIX.4.4")
+	msg.Header.SetString(tagMsgType, "D")
+	msg.Body.SetString(Tag(55), "MSFT")
+	msg.Body.SetString(Tag(11), "ID")
+
+	parties := NewRepeatingGroup(Tag(453), GroupTemplate{GroupElement(448), GroupElement(447)})
+	parties.Add().SetString(Tag(448), "P1").SetString(Tag(447), "D")
+	parties.Add().SetString(Tag(448), "P2")
+	msg.Body.SetGroup(parties)
+
+	s.Equal(`Header
+  8 BeginString = FIX.4.4
+  35 MsgType = D (NEWORDERSINGLE)
+Body
+  11 ClOrdID = ID
+  55 Symbol = MSFT
+  453 NoPartyIDs = 2
+    [1]
+      448 PartyID = P1
+      447 PartyIDSource = D (PROPCODE)
+    [2]
+      448 PartyID = P2
+Trailer
+`, FormatMessage(msg, s.dict, FormatOptions{}))
+}
@@ -2,7 +2,6 @@ package quickfix
 
 import (
 	"bytes"
-	"time"
 
 	"github.com/quickfixgo/quickfix/internal"
 )
@@ -35,6 +34,8 @@ func (state inSession) FixMsgIn(session *session, msg *Message) sessionState {
 		return state.handleSequenceReset(session, msg)
 	case bytes.Equal(msgTypeTestRequest, msgType):
 		return state.handleTestRequest(session, msg)
+	case bytes.Equal(msgTypeHeartbeat, msgType):
+		return state.handleHeartbeat(session, msg)
 	default:
 		if err := session.verify(msg); err != nil {
 			return state.processReject(session, msg, err)
@@ -57,14 +58,10 @@ func (state inSession) Timeout(session *session, event internal.Event) (nextStat
 			return handleStateError(session, err)
 		}
 	case internal.PeerTimeout:
-		testReq := NewMessage()
-		testReq.Header.SetField(tagMsgType, FIXString("1"))
-		testReq.Body.SetField(tagTestReqID, FIXString("TEST"))
-		if err := session.send(testReq); err != nil {
+		session.pendingTestReqIDs = nil
+		if err := session.sendTestRequest(); err != nil {
 			return handleStateError(session, err)
 		}
-		session.log.OnEvent("Sent test request TEST")
-		session.peerTimer.Reset(time.Duration(float64(1.2) * float64(session.HeartBtInt)))
 		return pendingTimeout{state}
 	}
 
@@ -100,6 +97,20 @@ func (state inSession) handleLogout(session *session, msg *Message) (nextState s
 	return latentState{}
 }
 
+func (state inSession) handleHeartbeat(session *session, msg *Message) (nextState sessionState) {
+	if err := session.verify(msg); err != nil {
+		return state.processReject(session, msg, err)
+	}
+
+	session.checkHeartbeatTestReqID(msg)
+
+	if err := session.store.IncrNextTargetMsgSeqNum(); err != nil {
+		return handleStateError(session, err)
+	}
+
+	return state
+}
+
 func (state inSession) handleTestRequest(session *session, msg *Message) (nextState sessionState) {
 	if err := session.verify(msg); err != nil {
 		return state.processReject(session, msg, err)
@@ -177,9 +188,8 @@ func (state inSession) handleResendRequest(session *session, msg *Message) (next
 	session.log.OnEventf("Received ResendRequest FROM: %d TO: %d", beginSeqNo, endSeqNo)
 	expectedSeqNum := session.store.NextSenderMsgSeqNum()
 
-	if (session.sessionID.BeginString >= BeginStringFIX42 && endSeqNo == 0) ||
-		(session.sessionID.BeginString <= BeginStringFIX42 && endSeqNo == 999999) ||
-		(endSeqNo >= expectedSeqNum) {
+	//EndSeqNo 0 and 999999 both request all messages, whatever the version
+	if endSeqNo == 0 || endSeqNo == 999999 || endSeqNo >= expectedSeqNum {
 		endSeqNo = expectedSeqNum - 1
 	}
 
@@ -238,7 +248,7 @@ func (state inSession) resendMessages(session *session, beginSeqNo, endSeqNo int
 		}
 
 		session.log.OnEventf("Resending Message: %v", sentMessageSeqNum)
-		msgBytes = msg.build()
+		msgBytes = msg.buildTo(getOutboundBuffer())
 		session.sendBytes(msgBytes)
 
 		seqNum = sentMessageSeqNum + 1
@@ -274,9 +284,17 @@ func (state inSession) processReject(session *session, msg *Message, rej Message
 			nextState.messageStash = make(map[int]*Message)
 		}
 
+		if session.MaxMessageStashSize > 0 && len(nextState.messageStash) >= session.MaxMessageStashSize {
+			session.log.OnEventf("Message stash full with %v messages while resending, logging out", len(nextState.messageStash))
+			if err := session.initiateLogout("Too many messages received while resending"); err != nil {
+				return handleStateError(session, err)
+			}
+			return logoutState{}
+		}
+
 		nextState.messageStash[TypedError.ReceivedTarget] = msg
-		//do not reclaim stashed message
-		msg.keepMessage = true
+		//stashed message is released once processed
+		msg.Retain()
 
 		return nextState
 
@@ -330,6 +348,11 @@ func (state inSession) doTargetTooLow(session *session, msg *Message, rej target
 	}
 
 	if !msg.Header.Has(tagOrigSendingTime) {
+		//lenient compliance ignores the duplicate as it would one with a valid OrigSendingTime
+		if session.LenientCompliance {
+			return state
+		}
+
 		if err := session.doReject(msg, RequiredTagMissing(tagOrigSendingTime)); err != nil {
 			return handleStateError(session, err)
 		}
@@ -380,7 +403,7 @@ func (state *inSession) generateSequenceReset(session *session, beginSeqNo int,
 
 	session.application.ToAdmin(sequenceReset, session.sessionID)
 
-	msgBytes := sequenceReset.build()
+	msgBytes := sequenceReset.buildTo(getOutboundBuffer())
 
 	session.sendBytes(msgBytes)
 	session.log.OnEventf("Sent SequenceReset TO: %v", endSeqNo)
@@ -102,6 +102,44 @@ func (s *InSessionTestSuite) TestTimeoutPeerTimeout() {
 	s.NextSenderMsgSeqNum(2)
 }
 
+func (s *InSessionTestSuite) TestTimeoutPeerTimeoutUniqueTestReqID() {
+	s.MockApp.On("ToAdmin").Return(nil)
+
+	var testReqIDs []string
+	for i := 0; i < 2; i++ {
+		s.session.State = inSession{}
+		s.session.Timeout(s.session, internal.PeerTimeout)
+		s.LastToAdminMessageSent()
+
+		var testReqID FIXString
+		s.Require().Nil(s.MockApp.lastToAdmin.Body.GetField(tagTestReqID, &testReqID))
+		testReqIDs = append(testReqIDs, string(testReqID))
+		s.Equal([]string{string(testReqID)}, s.session.pendingTestReqIDs)
+	}
+
+	s.NotEqual(testReqIDs[0], testReqIDs[1])
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInHeartbeatMatchesTestRequest() {
+	s.MockApp.On("ToAdmin").Return(nil)
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.Require().Len(s.session.pendingTestReqIDs, 1)
+
+	heartbeat := s.Heartbeat()
+	heartbeat.Body.SetField(tagTestReqID, FIXString("UNKNOWN"))
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.fixMsgIn(s.session, heartbeat)
+	s.State(pendingTimeout{inSession{}})
+	s.Len(s.session.pendingTestReqIDs, 1, "unknown TestReqID should not match")
+
+	heartbeat = s.Heartbeat()
+	heartbeat.Body.SetField(tagTestReqID, FIXString(s.session.pendingTestReqIDs[0]))
+	s.fixMsgIn(s.session, heartbeat)
+	s.State(inSession{})
+	s.Empty(s.session.pendingTestReqIDs)
+	s.NextTargetMsgSeqNum(3)
+}
+
 func (s *InSessionTestSuite) TestDisconnected() {
 	s.MockApp.On("OnLogout").Return(nil)
 	s.session.Disconnected(s.session)
@@ -203,6 +241,82 @@ func (s *InSessionTestSuite) TestFIXMsgInTargetTooHighResendRequestChunkSize() {
 	}
 }
 
+func (s *InSessionTestSuite) TestFIXMsgInTargetTooHighResendRequestEndSeqNoStyle() {
+	var tests = []struct {
+		style            internal.ResendRequestEndSeqNoStyle
+		chunkSize        int
+		expectedEndSeqNo int
+	}{
+		{internal.EndSeqNoDefault, 0, 0},
+		{internal.EndSeqNoZero, 0, 0},
+		{internal.EndSeqNoInfinity, 0, 999999},
+		{internal.EndSeqNoExplicit, 0, 5},
+		{internal.EndSeqNoInfinity, 10, 999999},
+		{internal.EndSeqNoExplicit, 10, 5},
+		{internal.EndSeqNoInfinity, 2, 2},
+	}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.MessageFactory.seqNum = 5
+		s.session.ResendRequestEndSeqNoStyle = test.style
+		s.session.ResendRequestChunkSize = test.chunkSize
+
+		s.MockApp.On("ToAdmin")
+		s.fixMsgIn(s.session, s.NewOrderSingle())
+
+		s.MockApp.AssertExpectations(s.T())
+		s.LastToAdminMessageSent()
+		s.MessageType(string(msgTypeResendRequest), s.MockApp.lastToAdmin)
+		s.FieldEquals(tagBeginSeqNo, 1, s.MockApp.lastToAdmin.Body)
+		s.FieldEquals(tagEndSeqNo, test.expectedEndSeqNo, s.MockApp.lastToAdmin.Body)
+		s.State(resendState{})
+	}
+}
+
+func (s *InSessionTestSuite) TestResendRequestEndSeqNoDefault() {
+	var tests = []struct {
+		beginString      string
+		expectedEndSeqNo int
+	}{
+		{BeginStringFIX40, 999999},
+		{BeginStringFIX41, 999999},
+		{BeginStringFIX42, 0},
+		{BeginStringFIX44, 0},
+		{BeginStringFIXT11, 0},
+	}
+
+	for _, test := range tests {
+		s.session.sessionID.BeginString = test.beginString
+		s.Equal(test.expectedEndSeqNo, s.session.resendRequestEndSeqNo(5))
+	}
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInResendRequestEndSeqNoForms() {
+	for _, beginString := range []string{BeginStringFIX41, BeginStringFIX44} {
+		for _, endSeqNo := range []int{0, 999999, 3} {
+			s.SetupTest()
+			s.session.sessionID.BeginString = beginString
+
+			s.MockApp.On("ToApp").Return(nil)
+			s.Require().Nil(s.session.send(s.NewOrderSingle()))
+			s.Require().Nil(s.session.send(s.NewOrderSingle()))
+			s.Require().Nil(s.session.send(s.NewOrderSingle()))
+			s.NextSenderMsgSeqNum(4)
+
+			resendRequest := s.ResendRequest(1)
+			resendRequest.Header.SetField(tagBeginString, FIXString(beginString))
+			resendRequest.Body.SetField(tagEndSeqNo, FIXInt(endSeqNo))
+
+			s.MockApp.On("FromAdmin").Return(nil)
+			s.fixMsgIn(s.session, resendRequest)
+
+			s.MockApp.AssertNumberOfCalls(s.T(), "ToApp", 6)
+			s.State(inSession{})
+		}
+	}
+}
+
 func (s *InSessionTestSuite) TestFIXMsgInResendRequestAllAdminExpectGapFill() {
 	s.MockApp.On("ToAdmin")
 	s.session.Timeout(s.session, internal.NeedHeartbeat)
@@ -273,6 +387,32 @@ func (s *InSessionTestSuite) TestFIXMsgInResendRequestAllAdminThenApp() {
 	s.State(inSession{})
 }
 
+func (s *InSessionTestSuite) TestFIXMsgInResendRequestPreservesTimestampPrecision() {
+	s.session.timestampPrecision = Picos
+
+	s.MockApp.On("ToApp").Return(nil)
+	nos := s.NewOrderSingle()
+	nos.Body.SetString(Tag(60), "20160208-22:07:16.954123123456")
+	s.Require().Nil(s.session.send(nos))
+	s.LastToAppMessageSent()
+
+	sendingTime, err := s.MockApp.lastToApp.Header.GetString(tagSendingTime)
+	s.Require().Nil(err)
+	s.Len(sendingTime, len("20060102-15:04:05.000000000000"))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.fixMsgIn(s.session, s.ResendRequest(1))
+
+	s.LastToAppMessageSent()
+	s.FieldEquals(tagPossDupFlag, true, s.MockApp.lastToApp.Header)
+	s.FieldEquals(tagOrigSendingTime, sendingTime, s.MockApp.lastToApp.Header)
+	s.FieldEquals(Tag(60), "20160208-22:07:16.954123123456", s.MockApp.lastToApp.Body)
+
+	_, precision, err := s.MockApp.lastToApp.Header.GetTimeWithPrecision(tagOrigSendingTime)
+	s.Nil(err)
+	s.Equal(Picos, precision)
+}
+
 func (s *InSessionTestSuite) TestFIXMsgInResendRequestNoMessagePersist() {
 	s.session.DisableMessagePersist = true
 
@@ -375,3 +515,161 @@ func (s *InSessionTestSuite) TestFIXMsgInTargetTooLowPossDup() {
 	s.State(inSession{})
 	s.NextTargetMsgSeqNum(2)
 }
+
+func (s *InSessionTestSuite) TestFIXMsgInPossDupNoOrigSendingTimeStrictCompliance() {
+	s.session.StrictCompliance = true
+
+	s.MockApp.On("ToAdmin")
+	nos := s.NewOrderSingle()
+	nos.Header.SetField(tagPossDupFlag, FIXBoolean(true))
+
+	s.fixMsgIn(s.session, nos)
+	s.MockApp.AssertNotCalled(s.T(), "FromApp")
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeReject), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagRefTagID, int(tagOrigSendingTime), s.MockApp.lastToAdmin.Body)
+	s.State(inSession{})
+	s.NextTargetMsgSeqNum(2)
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInPossDupOrigSendingTimeTooHighStrictCompliance() {
+	s.session.StrictCompliance = true
+
+	s.MockApp.On("ToAdmin")
+	nos := s.NewOrderSingle()
+	nos.Header.SetField(tagPossDupFlag, FIXBoolean(true))
+	nos.Header.SetField(tagOrigSendingTime, FIXUTCTimestamp{Time: time.Now().Add(time.Minute)})
+
+	s.fixMsgIn(s.session, nos)
+	s.MockApp.AssertNotCalled(s.T(), "FromApp")
+	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+	s.State(logoutState{})
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInPossDupNoOrigSendingTime() {
+	var tests = []struct {
+		name    string
+		lenient bool
+	}{
+		{"standard", false},
+		{"lenient", true},
+	}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.session.LenientCompliance = test.lenient
+
+		s.MockApp.On("FromApp").Return(nil)
+		nos := s.NewOrderSingle()
+		nos.Header.SetField(tagPossDupFlag, FIXBoolean(true))
+
+		s.fixMsgIn(s.session, nos)
+		s.MockApp.AssertExpectations(s.T())
+		s.NoMessageSent()
+		s.State(inSession{})
+		s.NextTargetMsgSeqNum(2)
+	}
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInTargetTooLowPossDupLenientCompliance() {
+	s.session.LenientCompliance = true
+	s.IncrNextTargetMsgSeqNum()
+
+	s.MockApp.On("ToAdmin")
+	nos := s.NewOrderSingle()
+	nos.Header.SetField(tagPossDupFlag, FIXBoolean(true))
+	s.fixMsgIn(s.session, nos)
+	s.MockApp.AssertNotCalled(s.T(), "FromApp")
+	s.NoMessageSent()
+	s.State(inSession{})
+	s.NextTargetMsgSeqNum(2)
+
+	nos.Header.SetField(tagOrigSendingTime, FIXUTCTimestamp{Time: time.Now().Add(time.Minute)})
+	s.fixMsgIn(s.session, nos)
+	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+	s.State(logoutState{})
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInSequenceResetNewSeqNoLess() {
+	s.IncrNextTargetMsgSeqNum()
+	s.IncrNextTargetMsgSeqNum()
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.SequenceReset(2))
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeReject), s.MockApp.lastToAdmin)
+	s.NextTargetMsgSeqNum(3)
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInSequenceResetNewSeqNoLessLenientCompliance() {
+	s.session.LenientCompliance = true
+	s.IncrNextTargetMsgSeqNum()
+	s.IncrNextTargetMsgSeqNum()
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.SequenceReset(2))
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeReject), s.MockApp.lastToAdmin)
+	s.NextTargetMsgSeqNum(3)
+}
+
+type mockPossResendApp struct {
+	*MockApp
+	possResend bool
+}
+
+func (a *mockPossResendApp) FromAppPossResend(msg *Message, sessionID SessionID, possResend bool) MessageRejectError {
+	a.possResend = possResend
+	return nil
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInPossResend() {
+	app := &mockPossResendApp{MockApp: &s.MockApp}
+	s.session.application = app
+
+	nos := s.NewOrderSingle()
+	nos.Header.SetField(tagPossResend, FIXBoolean(true))
+	s.fixMsgIn(s.session, nos)
+	s.True(app.possResend)
+
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.False(app.possResend)
+
+	s.MockApp.AssertNotCalled(s.T(), "FromApp")
+	s.NextTargetMsgSeqNum(3)
+}
+
+func (s *InSessionTestSuite) TestFIXMsgInInvalidPossResend() {
+	var tests = []struct {
+		name     string
+		lenient  bool
+		rejected bool
+	}{
+		{"standard", false, true},
+		{"lenient", true, false},
+	}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.session.LenientCompliance = test.lenient
+		app := &mockPossResendApp{MockApp: &s.MockApp, possResend: true}
+		s.session.application = app
+
+		s.MockApp.On("ToAdmin")
+		nos := s.NewOrderSingle()
+		nos.Header.SetString(tagPossResend, "X")
+		s.fixMsgIn(s.session, nos)
+
+		if test.rejected {
+			s.MessageType(string(msgTypeReject), s.MockApp.lastToAdmin)
+			s.True(app.possResend, test.name)
+		} else {
+			s.NoMessageSent()
+			s.False(app.possResend, test.name)
+		}
+		s.State(inSession{})
+		s.NextTargetMsgSeqNum(2)
+	}
+}
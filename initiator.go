@@ -73,7 +73,7 @@ func NewInitiator(app Application, storeFactory MessageStoreFactory, appSettings
 		sessionSettings: appSettings.SessionSettings(),
 		logFactory:      logFactory,
 		sessions:        make(map[SessionID]*session),
-		sessionFactory:  sessionFactory{true},
+		sessionFactory:  sessionFactory{BuildInitiators: true},
 	}
 
 	var err error
@@ -118,8 +118,12 @@ func (i *Initiator) waitForInSessionTime(session *session) bool {
 
 //watiForReconnectInterval returns true if a reconnect should be re-attempted, false if handler should stop
 func (i *Initiator) waitForReconnectInterval(reconnectInterval time.Duration) bool {
+	elapsed := make(chan interface{})
+	timer := i.sessionClock().AfterFunc(reconnectInterval, func() { close(elapsed) })
+	defer timer.Stop()
+
 	select {
-	case <-time.After(reconnectInterval):
+	case <-elapsed:
 	case <-i.stopChan:
 		return false
 	}
@@ -178,7 +182,7 @@ func (i *Initiator) handleConnection(session *session, tlsConfig *tls.Config, di
 
 		msgIn = make(chan fixIn, bufferedChanSize)
 		msgOut = make(chan []byte, bufferedChanSize)
-		if err := session.connect(msgIn, msgOut); err != nil {
+		if err := session.connect(msgIn, msgOut, netConn); err != nil {
 			session.log.OnEventf("Failed to initiate: %v", err)
 			goto reconnect
 		}
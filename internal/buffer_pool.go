@@ -5,33 +5,42 @@ import (
 	"sync"
 )
 
-//BufferPool is an concurrently safe pool for byte buffers.  Used to constructing inbound messages and writing outbound messages
+//DefaultMaxBufferSize is the capacity above which buffers are dropped rather than pooled, when BufferPool.MaxBufferSize is not set
+const DefaultMaxBufferSize = 64 * 1024
+
+//BufferPool is an concurrently safe pool for byte buffers.  Used to constructing inbound messages and writing outbound messages.
+//The zero value is ready to use and may be shared by any number of sessions.
 type BufferPool struct {
-	b []*bytes.Buffer
-	sync.Mutex
+	//MaxBufferSize caps the capacity of pooled buffers, larger buffers are left to the garbage collector
+	MaxBufferSize int
+
+	pool sync.Pool
 }
 
-//Get returns a buffer from the pool, or creates a new buffer if the pool is empty
-func (p *BufferPool) Get() (buf *bytes.Buffer) {
-	p.Lock()
-	if len(p.b) > 0 {
-		buf, p.b = p.b[len(p.b)-1], p.b[:len(p.b)-1]
-	} else {
-		buf = new(bytes.Buffer)
+//Get returns an empty buffer from the pool, or creates a new buffer if the pool is empty
+func (p *BufferPool) Get() *bytes.Buffer {
+	if buf, ok := p.pool.Get().(*bytes.Buffer); ok {
+		buf.Reset()
+		return buf
 	}
 
-	p.Unlock()
-
-	return
+	return new(bytes.Buffer)
 }
 
-//Put returns adds a buffer to the pool
+//Put returns adds a buffer to the pool. The caller must not use the buffer afterwards.
 func (p *BufferPool) Put(buf *bytes.Buffer) {
 	if buf == nil {
 		panic("Nil Buffer inserted into pool")
 	}
 
-	p.Lock()
-	p.b = append(p.b, buf)
-	p.Unlock()
+	maxSize := p.MaxBufferSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxBufferSize
+	}
+
+	if buf.Cap() > maxSize {
+		return
+	}
+
+	p.pool.Put(buf)
 }
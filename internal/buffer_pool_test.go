@@ -0,0 +1,57 @@
+package internal
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferPoolGetIsEmpty(t *testing.T) {
+	var p BufferPool
+
+	buf := p.Get()
+	buf.WriteString("8=FIX.4.2")
+	p.Put(buf)
+
+	assert.Equal(t, 0, p.Get().Len())
+}
+
+func TestBufferPoolDropsLargeBuffers(t *testing.T) {
+	p := BufferPool{MaxBufferSize: 16}
+
+	large := bytes.NewBuffer(make([]byte, 0, 32))
+	p.Put(large)
+
+	for i := 0; i < 10; i++ {
+		assert.False(t, p.Get() == large, "buffers above MaxBufferSize should not be pooled")
+	}
+}
+
+func TestBufferPoolPutNil(t *testing.T) {
+	var p BufferPool
+	assert.Panics(t, func() { p.Put(nil) })
+}
+
+func TestBufferPoolConcurrent(t *testing.T) {
+	var p BufferPool
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(id byte) {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				buf := p.Get()
+				buf.Write(bytes.Repeat([]byte{id}, 64))
+				if !assert.Equal(t, bytes.Repeat([]byte{id}, 64), buf.Bytes()) {
+					return
+				}
+				p.Put(buf)
+			}
+		}(byte(i))
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,138 @@
+package internal
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	calendarDateForm = "2006-01-02"
+	icsDateForm      = "20060102"
+)
+
+type calendarDate struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func calendarDateOf(t time.Time) calendarDate {
+	year, month, day := t.Date()
+	return calendarDate{year, month, day}
+}
+
+//Calendar is a set of dates, such as exchange holidays, on which there is no session
+type Calendar struct {
+	dates map[calendarDate]bool
+}
+
+//NewCalendar returns a calendar excluding the given dates. Only the year, month and day of each date are used.
+func NewCalendar(dates ...time.Time) *Calendar {
+	c := &Calendar{dates: make(map[calendarDate]bool)}
+	for _, date := range dates {
+		c.dates[calendarDateOf(date)] = true
+	}
+
+	return c
+}
+
+//ParseCalendar parses a calendar from either a list of dates in the format YYYY-MM-DD, one per line, or an
+//iCalendar (ICS) file of all day events. Blank lines and lines beginning with # are ignored in date lists.
+func ParseCalendar(reader io.Reader) (*Calendar, error) {
+	c := NewCalendar()
+	scanner := bufio.NewScanner(reader)
+
+	lineNumber := 0
+	isICS := false
+	var eventStart, eventEnd time.Time
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+
+		case line == "BEGIN:VCALENDAR":
+			isICS = true
+
+		case !isICS:
+			date, err := time.Parse(calendarDateForm, line)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing calendar line %v: %v", lineNumber, line)
+			}
+			c.dates[calendarDateOf(date)] = true
+
+		case line == "BEGIN:VEVENT":
+			eventStart, eventEnd = time.Time{}, time.Time{}
+
+		case strings.HasPrefix(line, "DTSTART"), strings.HasPrefix(line, "DTEND"):
+			date, err := parseICSDate(line)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing calendar line %v: %v", lineNumber, line)
+			}
+
+			if strings.HasPrefix(line, "DTSTART") {
+				eventStart = date
+			} else {
+				eventEnd = date
+			}
+
+		case line == "END:VEVENT":
+			if eventStart.IsZero() {
+				return nil, fmt.Errorf("calendar event ending on line %v has no DTSTART", lineNumber)
+			}
+
+			//DTEND of an all day event is exclusive
+			c.dates[calendarDateOf(eventStart)] = true
+			for date := eventStart.AddDate(0, 0, 1); date.Before(eventEnd); date = date.AddDate(0, 0, 1) {
+				c.dates[calendarDateOf(date)] = true
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+//parseICSDate parses the date of a DTSTART or DTEND property, e.g. DTSTART;VALUE=DATE:20241225
+func parseICSDate(line string) (time.Time, error) {
+	i := strings.LastIndex(line, ":")
+	if i < 0 || len(line)-i-1 < len(icsDateForm) {
+		return time.Time{}, fmt.Errorf("invalid date property: %v", line)
+	}
+
+	return time.Parse(icsDateForm, line[i+1:i+1+len(icsDateForm)])
+}
+
+//Contains returns true if the date of t, in the location of t, is in the calendar
+func (c *Calendar) Contains(t time.Time) bool {
+	if c == nil {
+		return false
+	}
+
+	return c.dates[calendarDateOf(t)]
+}
+
+//containsBetween returns true if any date from t1 through t2 inclusive is in the calendar
+func (c *Calendar) containsBetween(t1, t2 time.Time) bool {
+	if c == nil || len(c.dates) == 0 {
+		return false
+	}
+
+	last := calendarDateOf(t2)
+	for date := t1; ; date = date.AddDate(0, 0, 1) {
+		if c.Contains(date) {
+			return true
+		}
+
+		if calendarDateOf(date) == last || date.After(t2) {
+			return false
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package internal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCalendarDates(t *testing.T) {
+	c, err := ParseCalendar(strings.NewReader(`
+# exchange holidays
+2024-12-25
+
+2025-01-01
+`))
+	require.Nil(t, err)
+
+	assert.True(t, c.Contains(time.Date(2024, time.December, 25, 13, 0, 0, 0, time.UTC)))
+	assert.True(t, c.Contains(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, c.Contains(time.Date(2024, time.December, 24, 23, 59, 59, 0, time.UTC)))
+
+	_, err = ParseCalendar(strings.NewReader("12/25/2024"))
+	assert.NotNil(t, err)
+}
+
+func TestParseCalendarICS(t *testing.T) {
+	c, err := ParseCalendar(strings.NewReader(`BEGIN:VCALENDAR
+VERSION:2.0
+BEGIN:VEVENT
+SUMMARY:Christmas
+DTSTART;VALUE=DATE:20241225
+DTEND;VALUE=DATE:20241227
+END:VEVENT
+BEGIN:VEVENT
+SUMMARY:New Year
+DTSTART;VALUE=DATE:20250101
+END:VEVENT
+END:VCALENDAR
+`))
+	require.Nil(t, err)
+
+	assert.True(t, c.Contains(time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)))
+	assert.True(t, c.Contains(time.Date(2024, time.December, 26, 0, 0, 0, 0, time.UTC)))
+	assert.False(t, c.Contains(time.Date(2024, time.December, 27, 0, 0, 0, 0, time.UTC)), "DTEND is exclusive")
+	assert.True(t, c.Contains(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)))
+
+	_, err = ParseCalendar(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nEND:VEVENT\n"))
+	assert.NotNil(t, err)
+}
+
+func TestTimeRangeWithCalendar(t *testing.T) {
+	holiday := time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)
+	r := NewUTCTimeRange(NewTimeOfDay(8, 0, 0), NewTimeOfDay(17, 0, 0)).WithCalendar(NewCalendar(holiday))
+
+	assert.True(t, r.IsInRange(time.Date(2024, time.December, 24, 12, 0, 0, 0, time.UTC)))
+	assert.False(t, r.IsInRange(time.Date(2024, time.December, 25, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, r.IsInRange(time.Date(2024, time.December, 26, 12, 0, 0, 0, time.UTC)))
+
+	//calendar dates are in the time range location
+	loc, err := time.LoadLocation("America/New_York")
+	require.Nil(t, err)
+	r = NewTimeRangeInLocation(NewTimeOfDay(0, 0, 0), NewTimeOfDay(23, 59, 59), loc).WithCalendar(NewCalendar(holiday))
+	assert.True(t, r.IsInRange(time.Date(2024, time.December, 25, 3, 0, 0, 0, time.UTC)))
+	assert.False(t, r.IsInRange(time.Date(2024, time.December, 25, 6, 0, 0, 0, time.UTC)))
+}
+
+func TestTimeRangeIsInSameRangeWithCalendar(t *testing.T) {
+	holiday := time.Date(2024, time.December, 25, 0, 0, 0, 0, time.UTC)
+	r := NewUTCWeekRange(NewTimeOfDay(8, 0, 0), NewTimeOfDay(17, 0, 0), time.Monday, time.Friday).WithCalendar(NewCalendar(holiday))
+
+	//Monday 2024-12-23 through Friday 2024-12-27, interrupted by the Wednesday holiday
+	monday := time.Date(2024, time.December, 23, 9, 0, 0, 0, time.UTC)
+	tuesday := time.Date(2024, time.December, 24, 9, 0, 0, 0, time.UTC)
+	thursday := time.Date(2024, time.December, 26, 9, 0, 0, 0, time.UTC)
+	friday := time.Date(2024, time.December, 27, 9, 0, 0, 0, time.UTC)
+
+	assert.True(t, r.IsInSameRange(monday, tuesday))
+	assert.False(t, r.IsInSameRange(tuesday, thursday))
+	assert.False(t, r.IsInSameRange(thursday, monday))
+	assert.True(t, r.IsInSameRange(thursday, friday))
+}
@@ -0,0 +1,51 @@
+package internal
+
+import "time"
+
+//Clock is the source of time and timers for the session engine
+type Clock interface {
+	//Now returns the current time
+	Now() time.Time
+
+	//AfterFunc calls f in its own goroutine after duration d
+	AfterFunc(d time.Duration, f func()) Timer
+
+	//NewTicker returns a Ticker delivering the time on its channel every period d
+	NewTicker(d time.Duration) Ticker
+}
+
+//Timer is a single event created by Clock.AfterFunc
+type Timer interface {
+	//Stop prevents the Timer from firing. Returns false if the timer has already fired or been stopped.
+	Stop() bool
+
+	//Reset changes the timer to fire after duration d. Returns true if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+//Ticker delivers ticks at intervals
+type Ticker interface {
+	//C returns the channel on which ticks are delivered
+	C() <-chan time.Time
+
+	//Stop turns off the ticker
+	Stop()
+}
+
+//SystemClock is the Clock reading the wall clock, using the timers of package time
+type SystemClock struct{}
+
+//Now implements Clock
+func (SystemClock) Now() time.Time { return time.Now() }
+
+//AfterFunc implements Clock
+func (SystemClock) AfterFunc(d time.Duration, f func()) Timer { return time.AfterFunc(d, f) }
+
+//NewTicker implements Clock
+func (SystemClock) NewTicker(d time.Duration) Ticker { return systemTicker{time.NewTicker(d)} }
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time { return t.Ticker.C }
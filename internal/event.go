@@ -12,4 +12,6 @@ const (
 	LogonTimeout
 	//LogoutTimeout indicates the peer has not sent a logout request
 	LogoutTimeout
+	//ResendRequestTimeout indicates the peer has not answered a resend request
+	ResendRequestTimeout
 )
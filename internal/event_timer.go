@@ -5,48 +5,60 @@ import (
 	"time"
 )
 
+//EventTimer calls a task each time a timeout set with Reset elapses, using the timers of a Clock
 type EventTimer struct {
-	f     func()
-	timer *time.Timer
-	done  chan struct{}
-	wg    sync.WaitGroup
+	f       func()
+	clock   Clock
+	mu      sync.Mutex
+	timer   Timer
+	stopped bool
+	done    chan struct{}
+	firing  sync.WaitGroup
 }
 
-func NewEventTimer(task func()) *EventTimer {
-	t := &EventTimer{
-		f:     task,
-		timer: newStoppedTimer(),
-		done:  make(chan struct{}),
-	}
-
-	t.wg.Add(1)
-	go func() {
-		defer t.wg.Done()
-
-		for {
-			select {
-
-			case <-t.timer.C:
-				t.f()
+//NewEventTimer returns a stopped EventTimer for task. The timer starts on the first call to Reset.
+//
+//A task that blocks, such as on a channel send, should also select on Done so that Stop can return.
+func NewEventTimer(clock Clock, task func()) *EventTimer {
+	return &EventTimer{f: task, clock: clock, done: make(chan struct{})}
+}
 
-			case <-t.done:
-				t.timer.Stop()
-				return
+func (t *EventTimer) fire() {
+	t.mu.Lock()
+	if t.stopped {
+		t.mu.Unlock()
+		return
+	}
+	t.firing.Add(1)
+	t.mu.Unlock()
 
-			}
-		}
-	}()
+	defer t.firing.Done()
+	t.f()
+}
 
-	return t
+//Done returns a channel closed when the timer is stopped
+func (t *EventTimer) Done() <-chan struct{} {
+	return t.done
 }
 
+//Stop stops the timer, closes Done and waits for a task already running to return. Must not be called from the task.
 func (t *EventTimer) Stop() {
 	if t == nil {
 		return
 	}
 
-	close(t.done)
-	t.wg.Wait()
+	t.mu.Lock()
+	if !t.stopped {
+		t.stopped = true
+		close(t.done)
+	}
+
+	if t.timer != nil {
+		t.timer.Stop()
+	}
+	t.mu.Unlock()
+
+	t.firing.Wait()
 }
 
 func (t *EventTimer) Reset(timeout time.Duration) {
@@ -54,13 +66,17 @@ func (t *EventTimer) Reset(timeout time.Duration) {
 		return
 	}
 
-	t.timer.Reset(timeout)
-}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.stopped {
+		return
+	}
 
-func newStoppedTimer() *time.Timer {
-	timer := time.NewTimer(time.Second)
-	if !timer.Stop() {
-		<-timer.C
+	if t.timer == nil {
+		t.timer = t.clock.AfterFunc(timeout, t.fire)
+		return
 	}
-	return timer
+
+	t.timer.Reset(timeout)
 }
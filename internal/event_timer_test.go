@@ -0,0 +1,27 @@
+package internal
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventTimerReset(t *testing.T) {
+	fired := make(chan struct{}, 2)
+	timer := NewEventTimer(SystemClock{}, func() { fired <- struct{}{} })
+
+	timer.Reset(time.Millisecond)
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("timer should fire after Reset")
+	}
+
+	timer.Reset(time.Millisecond)
+	timer.Stop()
+	timer.Reset(time.Millisecond)
+	select {
+	case <-fired:
+		t.Error("stopped timer should not fire")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
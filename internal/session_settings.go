@@ -1,6 +1,23 @@
 package internal
 
-import "time"
+import (
+	"net"
+	"time"
+)
+
+//ResendRequestEndSeqNoStyle determines the EndSeqNo(16) of a ResendRequest for all messages from BeginSeqNo onwards
+type ResendRequestEndSeqNoStyle int
+
+const (
+	//EndSeqNoDefault is 0 for FIX.4.2 and later, 999999 for earlier versions
+	EndSeqNoDefault ResendRequestEndSeqNoStyle = iota
+	//EndSeqNoZero is always 0
+	EndSeqNoZero
+	//EndSeqNoInfinity is always 999999
+	EndSeqNoInfinity
+	//EndSeqNoExplicit is the last missing sequence number
+	EndSeqNoExplicit
+)
 
 //SessionSettings stores all of the configuration for a given session
 type SessionSettings struct {
@@ -10,13 +27,29 @@ type SessionSettings struct {
 	ResetOnDisconnect            bool
 	HeartBtInt                   time.Duration
 	SessionTime                  *TimeRange
+	LogonTime                    *TimeRange
 	InitiateLogon                bool
 	ResendRequestChunkSize       int
+	ResendRequestEndSeqNoStyle   ResendRequestEndSeqNoStyle
+	ResendRequestTimeout         time.Duration
+	MaxResendRequestRetries      int
+	MaxMessageStashSize          int
 	EnableLastMsgSeqNumProcessed bool
+	EnableNextExpectedMsgSeqNum  bool
 	SkipCheckLatency             bool
 	MaxLatency                   time.Duration
 	DisableMessagePersist        bool
 
+	//SessionComplianceLevel=strict applies additional session protocol checks, lenient ignores duplicates missing
+	//OrigSendingTime and passes an invalid PossResend as N
+	StrictCompliance  bool
+	LenientCompliance bool
+
+	//heartbeat supervision, multiples of HeartBtInt
+	TestRequestDelayMultiplier      float64
+	HeartbeatTimeoutMultiplier      float64
+	MaxTestRequestsBeforeDisconnect int
+
 	//required on logon for FIX.T.1 messages
 	DefaultApplVerID string
 
@@ -25,4 +58,10 @@ type SessionSettings struct {
 	LogoutTimeout        time.Duration
 	LogonTimeout         time.Duration
 	SocketConnectAddress []string
+
+	//specific to acceptors
+	AllowedRemoteAddresses  []*net.IPNet
+	MaxConnectionsPerRemote int
+	MinHeartBtInt           time.Duration
+	MaxHeartBtInt           time.Duration
 }
@@ -34,11 +34,25 @@ func ParseTimeOfDay(str string) (TimeOfDay, error) {
 	return NewTimeOfDay(t.Clock()), nil
 }
 
+//TimeWindow is a band within a single day, from Start through End
+type TimeWindow struct {
+	Start, End TimeOfDay
+}
+
+func (w TimeWindow) contains(t TimeOfDay) bool {
+	return w.Start.d <= t.d && t.d <= w.End.d
+}
+
 //TimeRange represents a time band in a given time zone
 type TimeRange struct {
 	startTime, endTime TimeOfDay
 	startDay, endDay   *time.Weekday
 	loc                *time.Location
+	calendar           *Calendar
+
+	//multiple daily windows, with optional overrides by day of week
+	windows    []TimeWindow
+	dayWindows map[time.Weekday][]TimeWindow
 }
 
 //NewUTCTimeRange returns a time range in UTC
@@ -69,6 +83,70 @@ func NewWeekRangeInLocation(startTime, endTime TimeOfDay, startDay, endDay time.
 	return r
 }
 
+//NewScheduleInLocation returns a time range of one or more windows each day. Windows for a day of week in dayWindows
+//replace the default windows on that day. Windows may not span midnight or overlap.
+func NewScheduleInLocation(windows []TimeWindow, dayWindows map[time.Weekday][]TimeWindow, loc *time.Location) (*TimeRange, error) {
+	if loc == nil {
+		panic("time: missing Location in call to NewScheduleInLocation")
+	}
+
+	if err := validateWindows(windows); err != nil {
+		return nil, err
+	}
+
+	for _, w := range dayWindows {
+		if err := validateWindows(w); err != nil {
+			return nil, err
+		}
+	}
+
+	return &TimeRange{windows: windows, dayWindows: dayWindows, loc: loc}, nil
+}
+
+func validateWindows(windows []TimeWindow) error {
+	for i, w := range windows {
+		if w.Start.d >= w.End.d {
+			return errors.New("session window must start before it ends and may not span midnight")
+		}
+
+		for _, other := range windows[:i] {
+			if w.Start.d <= other.End.d && other.Start.d <= w.End.d {
+				return errors.New("session windows may not overlap")
+			}
+		}
+	}
+
+	return nil
+}
+
+//WithCalendar excludes the dates in calendar, in the time range location, from the time range
+func (r *TimeRange) WithCalendar(calendar *Calendar) *TimeRange {
+	r.calendar = calendar
+	return r
+}
+
+func (r *TimeRange) isSchedule() bool {
+	return r.windows != nil || r.dayWindows != nil
+}
+
+//scheduleWindow returns the index of the window containing t on the day of t, or -1 if there is none
+func (r *TimeRange) scheduleWindow(t time.Time) int {
+	t = t.In(r.loc)
+	windows, ok := r.dayWindows[t.Weekday()]
+	if !ok {
+		windows = r.windows
+	}
+
+	timeOfDay := NewTimeOfDay(t.Clock())
+	for i, w := range windows {
+		if w.contains(timeOfDay) {
+			return i
+		}
+	}
+
+	return -1
+}
+
 func (r *TimeRange) isInTimeRange(t time.Time) bool {
 	t = t.In(r.loc)
 	ts := NewTimeOfDay(t.Clock()).d
@@ -127,6 +205,14 @@ func (r *TimeRange) IsInRange(t time.Time) bool {
 		return true
 	}
 
+	if r.calendar.Contains(t.In(r.loc)) {
+		return false
+	}
+
+	if r.isSchedule() {
+		return r.scheduleWindow(t) >= 0
+	}
+
 	if r.startDay != nil {
 		return r.isInWeekRange(t)
 	}
@@ -148,6 +234,12 @@ func (r *TimeRange) IsInSameRange(t1, t2 time.Time) bool {
 		t1, t2 = t2, t1
 	}
 
+	if r.isSchedule() {
+		//windows do not span midnight, so times in the same window are on the same day
+		t1, t2 = t1.In(r.loc), t2.In(r.loc)
+		return calendarDateOf(t1) == calendarDateOf(t2) && r.scheduleWindow(t1) == r.scheduleWindow(t2)
+	}
+
 	t1 = t1.In(r.loc)
 	t1Time := NewTimeOfDay(t1.Clock())
 	dayOffset := 0
@@ -174,5 +266,10 @@ func (r *TimeRange) IsInSameRange(t1, t2 time.Time) bool {
 	sessionEnd := time.Date(t1.Year(), t1.Month(), t1.Day(), r.endTime.hour, r.endTime.minute, r.endTime.second, 0, r.loc)
 	sessionEnd = sessionEnd.AddDate(0, 0, dayOffset)
 
-	return t2.Before(sessionEnd)
+	if !t2.Before(sessionEnd) {
+		return false
+	}
+
+	//a non-session date between t1 and t2 ends the session
+	return !r.calendar.containsBetween(t1, t2.In(r.loc))
 }
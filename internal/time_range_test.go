@@ -433,3 +433,73 @@ func TestTimeRangeIsInSameRangeWithDay(t *testing.T) {
 	time2 = time.Date(2006, time.December, 4, 9, 1, 0, 0, time.UTC)
 	assert.True(t, NewUTCWeekRange(startTime, endTime, startDay, endDay).IsInSameRange(time1, time2))
 }
+
+func TestScheduleIsInRange(t *testing.T) {
+	windows := []TimeWindow{
+		{NewTimeOfDay(7, 0, 0), NewTimeOfDay(12, 0, 0)},
+		{NewTimeOfDay(13, 30, 0), NewTimeOfDay(20, 0, 0)},
+	}
+	dayWindows := map[time.Weekday][]TimeWindow{
+		time.Friday:   {{NewTimeOfDay(7, 0, 0), NewTimeOfDay(12, 0, 0)}},
+		time.Saturday: {},
+	}
+
+	r, err := NewScheduleInLocation(windows, dayWindows, time.UTC)
+	assert.Nil(t, err)
+
+	var tests = []struct {
+		now      time.Time
+		expected bool
+	}{
+		//Monday 2024-12-16
+		{time.Date(2024, time.December, 16, 6, 59, 59, 0, time.UTC), false},
+		{time.Date(2024, time.December, 16, 7, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, time.December, 16, 12, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, time.December, 16, 12, 30, 0, 0, time.UTC), false},
+		{time.Date(2024, time.December, 16, 13, 30, 0, 0, time.UTC), true},
+		{time.Date(2024, time.December, 16, 20, 0, 1, 0, time.UTC), false},
+		//Friday override
+		{time.Date(2024, time.December, 20, 8, 0, 0, 0, time.UTC), true},
+		{time.Date(2024, time.December, 20, 14, 0, 0, 0, time.UTC), false},
+		//no session on Saturday
+		{time.Date(2024, time.December, 21, 8, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, r.IsInRange(test.now), "%v", test.now)
+	}
+}
+
+func TestScheduleIsInSameRange(t *testing.T) {
+	windows := []TimeWindow{
+		{NewTimeOfDay(7, 0, 0), NewTimeOfDay(12, 0, 0)},
+		{NewTimeOfDay(13, 30, 0), NewTimeOfDay(20, 0, 0)},
+	}
+	r, err := NewScheduleInLocation(windows, nil, time.UTC)
+	assert.Nil(t, err)
+
+	morning := time.Date(2024, time.December, 16, 8, 0, 0, 0, time.UTC)
+	lateMorning := time.Date(2024, time.December, 16, 11, 0, 0, 0, time.UTC)
+	afternoon := time.Date(2024, time.December, 16, 14, 0, 0, 0, time.UTC)
+
+	assert.True(t, r.IsInSameRange(morning, lateMorning))
+	assert.False(t, r.IsInSameRange(morning, afternoon))
+	assert.False(t, r.IsInSameRange(afternoon, morning))
+	assert.False(t, r.IsInSameRange(morning, morning.AddDate(0, 0, 1)))
+}
+
+func TestNewScheduleInvalidWindows(t *testing.T) {
+	_, err := NewScheduleInLocation([]TimeWindow{{NewTimeOfDay(22, 0, 0), NewTimeOfDay(6, 0, 0)}}, nil, time.UTC)
+	assert.NotNil(t, err, "windows may not span midnight")
+
+	_, err = NewScheduleInLocation([]TimeWindow{
+		{NewTimeOfDay(7, 0, 0), NewTimeOfDay(12, 0, 0)},
+		{NewTimeOfDay(11, 0, 0), NewTimeOfDay(14, 0, 0)},
+	}, nil, time.UTC)
+	assert.NotNil(t, err, "windows may not overlap")
+
+	_, err = NewScheduleInLocation(nil, map[time.Weekday][]TimeWindow{
+		time.Monday: {{NewTimeOfDay(12, 0, 0), NewTimeOfDay(12, 0, 0)}},
+	}, time.UTC)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,351 @@
+package quickfix
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+)
+
+//JSONOptions controls how messages are encoded as JSON
+type JSONOptions struct {
+	//EnumDescriptions writes the values of enumerated fields as their descriptions in the DataDictionary
+	EnumDescriptions bool
+}
+
+//JSONMessage wraps a Message with the DataDictionaries used to encode and decode it, implementing json.Marshaler and
+//json.Unmarshaler so messages can be embedded in other JSON documents
+type JSONMessage struct {
+	*Message
+	TransportDataDictionary *datadictionary.DataDictionary
+	AppDataDictionary       *datadictionary.DataDictionary
+	JSONOptions
+}
+
+//MarshalJSON implements json.Marshaler, see MarshalMessageJSON
+func (m JSONMessage) MarshalJSON() ([]byte, error) {
+	return MarshalMessageJSON(m.Message, m.TransportDataDictionary, m.AppDataDictionary, m.JSONOptions)
+}
+
+//UnmarshalJSON implements json.Unmarshaler, see UnmarshalMessageJSON
+func (m *JSONMessage) UnmarshalJSON(data []byte) error {
+	if m.Message == nil {
+		m.Message = NewMessage()
+	}
+
+	return UnmarshalMessageJSON(m.Message, data, m.TransportDataDictionary, m.AppDataDictionary)
+}
+
+//MarshalMessageJSON encodes msg as a JSON document of the form {"Header":{...},"Body":{...},"Trailer":{...}},
+//following the FIX JSON encoding. Fields are named after the optional transport and application DataDictionary,
+//fields missing from the dictionaries are keyed by tag number. Int fields are written as JSON numbers and boolean
+//fields as JSON booleans, all other values are written as strings in their FIX format, including decimals and
+//timestamps. Repeating groups are arrays of objects keyed by the name of their NumInGroup field.
+func MarshalMessageJSON(
+	msg *Message,
+	transportDataDictionary *datadictionary.DataDictionary,
+	applicationDataDictionary *datadictionary.DataDictionary,
+	options JSONOptions,
+) ([]byte, error) {
+	e := jsonCodec{dicts: newDataDictionaries(transportDataDictionary, applicationDataDictionary), options: options}
+
+	msgType, _ := msg.Header.GetBytes(tagMsgType)
+	headerDef, bodyDef, trailerDef := e.dicts.messageDefs(string(msgType))
+	header, body, trailer := e.dicts.messageFields(msg)
+
+	var err error
+	b := append(make([]byte, 0, 2*msg.Header.length()+2*msg.Body.length()), `{"Header":`...)
+	if b, err = e.appendObject(b, header, headerDef); err != nil {
+		return nil, err
+	}
+
+	b = append(b, `,"Body":`...)
+	if b, err = e.appendObject(b, body, bodyDef); err != nil {
+		return nil, err
+	}
+
+	b = append(b, `,"Trailer":`...)
+	if b, err = e.appendObject(b, trailer, trailerDef); err != nil {
+		return nil, err
+	}
+
+	return append(b, '}'), nil
+}
+
+//UnmarshalMessageJSON decodes a JSON document written by MarshalMessageJSON, or by another engine following the FIX
+//JSON encoding, into msg. Values may be JSON strings, numbers or booleans, and enumerated values may be given by their
+//description in the DataDictionary.
+func UnmarshalMessageJSON(
+	msg *Message,
+	data []byte,
+	transportDataDictionary *datadictionary.DataDictionary,
+	applicationDataDictionary *datadictionary.DataDictionary,
+) error {
+	d := jsonCodec{dicts: newDataDictionaries(transportDataDictionary, applicationDataDictionary)}
+
+	var sections struct {
+		Header  json.RawMessage
+		Body    json.RawMessage
+		Trailer json.RawMessage
+	}
+	if err := json.Unmarshal(data, &sections); err != nil {
+		return err
+	}
+
+	msg.Header.Clear()
+	msg.Body.Clear()
+	msg.Trailer.Clear()
+	msg.rawMessage = nil
+	msg.bodyBytes = nil
+
+	headerDef, _, trailerDef := d.dicts.messageDefs("")
+	if err := d.decodeFieldMap(&msg.Header.FieldMap, sections.Header, headerDef); err != nil {
+		return err
+	}
+
+	msgType, _ := msg.Header.GetBytes(tagMsgType)
+	_, bodyDef, _ := d.dicts.messageDefs(string(msgType))
+	if err := d.decodeFieldMap(&msg.Body.FieldMap, sections.Body, bodyDef); err != nil {
+		return err
+	}
+
+	return d.decodeFieldMap(&msg.Trailer.FieldMap, sections.Trailer, trailerDef)
+}
+
+type jsonCodec struct {
+	dicts   dataDictionaries
+	options JSONOptions
+}
+
+func (c jsonCodec) appendObject(b []byte, tvs []TagValue, defs fieldDefs) ([]byte, error) {
+	b = append(b, '{')
+	b, _, err := c.appendFields(b, tvs, defs, nil)
+	return append(b, '}'), err
+}
+
+//appendFields appends the fields of tvs as JSON object members. Within a group, fields are appended up to the next
+//instance of the group or the first field not part of the group, returning the fields remaining.
+func (c jsonCodec) appendFields(b []byte, tvs []TagValue, defs fieldDefs, groupDef *datadictionary.FieldDef) ([]byte, []TagValue, error) {
+	for first := true; len(tvs) > 0; first = false {
+		tv := tvs[0]
+		fieldDef := defs(tv.tag)
+		if groupDef != nil && (fieldDef == nil || (!first && tv.tag == Tag(groupDef.Fields[0].Tag()))) {
+			break
+		}
+
+		if !first {
+			b = append(b, ',')
+		}
+
+		fieldType := c.dicts.fieldType(tv.tag)
+		if fieldDef != nil {
+			fieldType = fieldDef.FieldType
+		}
+
+		if fieldType != nil {
+			b = appendJSONString(b, fieldType.Name())
+		} else {
+			b = appendJSONString(b, strconv.Itoa(int(tv.tag)))
+		}
+		b = append(b, ':')
+
+		tvs = tvs[1:]
+		if fieldDef == nil || !fieldDef.IsGroup() || len(fieldDef.Fields) == 0 {
+			b = c.appendValue(b, tv.value, fieldType)
+			continue
+		}
+
+		count, err := atoi(tv.value)
+		if err != nil {
+			return b, tvs, incorrectNumInGroupCountForRepeatingGroup(tv.tag)
+		}
+
+		b = append(b, '[')
+		for i := 0; i < count; i++ {
+			if len(tvs) == 0 || tvs[0].tag != Tag(fieldDef.Fields[0].Tag()) {
+				return b, tvs, fmt.Errorf("group %v: expected %v groups, but found %v", tv.tag, count, i)
+			}
+
+			if i > 0 {
+				b = append(b, ',')
+			}
+
+			b = append(b, '{')
+			if b, tvs, err = c.appendFields(b, tvs, groupDefFields(fieldDef), fieldDef); err != nil {
+				return b, tvs, err
+			}
+			b = append(b, '}')
+		}
+		b = append(b, ']')
+	}
+
+	return b, tvs, nil
+}
+
+func (c jsonCodec) appendValue(b []byte, value []byte, fieldType *datadictionary.FieldType) []byte {
+	if fieldType == nil {
+		return appendJSONString(b, string(value))
+	}
+
+	if c.options.EnumDescriptions {
+		if enum, ok := fieldType.Enums[string(value)]; ok {
+			return appendJSONString(b, enum.Description)
+		}
+	}
+
+	switch fieldType.Type {
+	case "INT", "LENGTH", "SEQNUM", "NUMINGROUP", "DAYOFMONTH", "TAGNUM":
+		if isJSONInt(value) {
+			return append(b, value...)
+		}
+	case "BOOLEAN":
+		switch string(value) {
+		case "Y":
+			return append(b, "true"...)
+		case "N":
+			return append(b, "false"...)
+		}
+	}
+
+	return appendJSONString(b, string(value))
+}
+
+func appendJSONString(b []byte, s string) []byte {
+	encoded, _ := json.Marshal(s)
+	return append(b, encoded...)
+}
+
+//isJSONInt returns true if value is an integer in the JSON number format, without leading zeros
+func isJSONInt(value []byte) bool {
+	if len(value) > 0 && value[0] == '-' {
+		value = value[1:]
+	}
+
+	if len(value) == 0 || (value[0] == '0' && len(value) > 1) {
+		return false
+	}
+
+	for _, b := range value {
+		if !isDecimal(b) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (c jsonCodec) decodeFieldMap(m *FieldMap, data json.RawMessage, defs fieldDefs) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	for name, raw := range members {
+		tag, fieldType, err := c.tag(name)
+		if err != nil {
+			return err
+		}
+
+		if len(raw) > 0 && raw[0] == '[' {
+			fieldDef := defs(tag)
+			if fieldDef == nil || !fieldDef.IsGroup() {
+				return fmt.Errorf("%v is not a repeating group", name)
+			}
+
+			var items []json.RawMessage
+			if err := json.Unmarshal(raw, &items); err != nil {
+				return err
+			}
+
+			rg := NewRepeatingGroup(tag, groupTemplate(fieldDef))
+			for _, item := range items {
+				if err := c.decodeFieldMap(&rg.Add().FieldMap, item, groupDefFields(fieldDef)); err != nil {
+					return err
+				}
+			}
+
+			m.SetGroup(rg)
+			continue
+		}
+
+		value, ok, err := c.value(raw, fieldType)
+		if err != nil {
+			return fmt.Errorf("%v: %v", name, err)
+		}
+
+		if ok {
+			m.SetBytes(tag, value)
+		}
+	}
+
+	return nil
+}
+
+//tag returns the tag and type of the field named name in either DataDictionary, or numbered name
+func (c jsonCodec) tag(name string) (Tag, *datadictionary.FieldType, error) {
+	for _, dict := range c.dicts {
+		if dict == nil {
+			continue
+		}
+
+		if fieldType, ok := dict.FieldTypeByName[name]; ok {
+			return Tag(fieldType.Tag()), fieldType, nil
+		}
+	}
+
+	tag, err := strconv.Atoi(name)
+	if err != nil || tag <= 0 {
+		return 0, nil, fmt.Errorf("unknown field %v", name)
+	}
+
+	return Tag(tag), c.dicts.fieldType(Tag(tag)), nil
+}
+
+//value returns the FIX value of a JSON string, number or boolean. Null values are skipped.
+func (c jsonCodec) value(raw json.RawMessage, fieldType *datadictionary.FieldType) (value []byte, ok bool, err error) {
+	switch {
+	case len(raw) == 0:
+		return nil, false, fmt.Errorf("missing value")
+
+	case raw[0] == '"':
+		var s string
+		if err = json.Unmarshal(raw, &s); err != nil {
+			return
+		}
+
+		if fieldType != nil {
+			if _, isValue := fieldType.Enums[s]; !isValue {
+				for _, enum := range fieldType.Enums {
+					if enum.Description == s {
+						s = enum.Value
+						break
+					}
+				}
+			}
+		}
+		return []byte(s), true, nil
+
+	case string(raw) == "true":
+		return []byte("Y"), true, nil
+
+	case string(raw) == "false":
+		return []byte("N"), true, nil
+
+	case string(raw) == "null":
+		return nil, false, nil
+
+	case raw[0] == '-' || isDecimal(raw[0]):
+		var n json.Number
+		if err = json.Unmarshal(raw, &n); err != nil {
+			return
+		}
+		return []byte(n.String()), true, nil
+	}
+
+	return nil, false, fmt.Errorf("unsupported value %s", raw)
+}
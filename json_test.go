@@ -0,0 +1,187 @@
+package quickfix
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONSuite struct {
+	suite.Suite
+	dict *datadictionary.DataDictionary
+}
+
+func TestJSONSuite(t *testing.T) {
+	suite.Run(t, new(JSONSuite))
+}
+
+func (s *JSONSuite) SetupSuite() {
+	var err error
+	s.dict, err = datadictionary.Parse("spec/FIX44.xml")
+	s.Require().Nil(err)
+}
+
+func (s *JSONSuite) parse(rawMsg string, transportDict, appDict *datadictionary.DataDictionary) *Message {
+	msg := NewMessage()
+	s.Require().Nil(ParseMessageWithDataDictionary(msg, newBuffer(rawMsg), transportDict, appDict))
+	return msg
+}
+
+//newBuffer returns rawMsg with '|' delimiters and a valid BodyLength and CheckSum
+func newBuffer(rawMsg string) *bytes.Buffer {
+	fields := strings.Split(strings.TrimSuffix(rawMsg, "|"), "|")
+	body := strings.Join(fields[2:len(fields)-1], "\x01") + "\x01"
+	msg := fmt.Sprintf("%v\x019=%v\x01%v", fields[0], len(body), body)
+
+	var checkSum int
+	for i := 0; i < len(msg); i++ {
+		checkSum += int(msg[i])
+	}
+
+	return bytes.NewBufferString(fmt.Sprintf("%v10=%03d\x01", msg, checkSum%256))
+}
+
+func (s *JSONSuite) TestMarshalNewOrderSingle() {
+	msg := s.parse("8=FIX.4.4|9=170|35=D|34=2|49=TW|52=20230101-10:00:00.123|56=ISLD|11=ID|21=1|38=100|40=2|44=10.50|54=1|55=MSFT|60=20230101-10:00:00|453=2|448=P1|447=D|452=1|802=1|523=S1|803=1|448=P2|447=D|452=3|10=000|", s.dict, s.dict)
+
+	b, err := MarshalMessageJSON(msg, s.dict, s.dict, JSONOptions{})
+	s.Require().Nil(err)
+	s.JSONEq(`{
+		"Header": {"BeginString": "FIX.4.4", "BodyLength": 178, "MsgType": "D", "MsgSeqNum": 2, "SenderCompID": "TW", "SendingTime": "20230101-10:00:00.123", "TargetCompID": "ISLD"},
+		"Body": {
+			"ClOrdID": "ID", "HandlInst": "1", "OrderQty": "100", "OrdType": "2", "Price": "10.50", "Side": "1", "Symbol": "MSFT", "TransactTime": "20230101-10:00:00",
+			"NoPartyIDs": [
+				{"PartyID": "P1", "PartyIDSource": "D", "PartyRole": 1, "NoPartySubIDs": [{"PartySubID": "S1", "PartySubIDType": 1}]},
+				{"PartyID": "P2", "PartyIDSource": "D", "PartyRole": 3}
+			]
+		},
+		"Trailer": {"CheckSum": "186"}
+	}`, string(b))
+}
+
+func (s *JSONSuite) TestMarshalParsedWithoutDataDictionary() {
+	msg := NewMessage()
+	s.Require().Nil(ParseMessage(msg, newBuffer("8=FIX.4.4|9=0|35=D|34=2|49=TW|56=ISLD|11=ID|54=1|453=2|448=P1|447=D|452=1|448=P2|447=D|452=3|10=000|")))
+
+	b, err := MarshalMessageJSON(msg, s.dict, s.dict, JSONOptions{})
+	s.Require().Nil(err)
+	s.JSONEq(`{
+		"Header": {"BeginString": "FIX.4.4", "BodyLength": 79, "MsgType": "D", "MsgSeqNum": 2, "SenderCompID": "TW", "TargetCompID": "ISLD"},
+		"Body": {
+			"ClOrdID": "ID", "Side": "1",
+			"NoPartyIDs": [
+				{"PartyID": "P1", "PartyIDSource": "D", "PartyRole": 1},
+				{"PartyID": "P2", "PartyIDSource": "D", "PartyRole": 3}
+			]
+		},
+		"Trailer": {"CheckSum": "028"}
+	}`, string(b))
+
+	decoded := NewMessage()
+	s.Require().Nil(UnmarshalMessageJSON(decoded, b, s.dict, s.dict))
+	s.Equal(msg.String(), decoded.String())
+}
+
+func (s *JSONSuite) TestMarshalEnumDescriptions() {
+	msg := s.parse("8=FIX.4.4|9=40|35=D|11=ID|40=2|54=1|10=000|", s.dict, s.dict)
+
+	b, err := MarshalMessageJSON(msg, s.dict, s.dict, JSONOptions{EnumDescriptions: true})
+	s.Require().Nil(err)
+	s.JSONEq(`{
+		"Header": {"BeginString": "FIX.4.4", "BodyLength": 21, "MsgType": "NEWORDERSINGLE"},
+		"Body": {"ClOrdID": "ID", "OrdType": "LIMIT", "Side": "BUY"},
+		"Trailer": {"CheckSum": "190"}
+	}`, string(b))
+
+	decoded := NewMessage()
+	s.Require().Nil(UnmarshalMessageJSON(decoded, b, s.dict, s.dict))
+	s.Equal(msg.String(), decoded.String())
+}
+
+func (s *JSONSuite) TestMarshalUnknownFields() {
+	msg := s.parse("8=FIX.4.4|9=40|35=D|11=ID|5000=custom|10=000|", s.dict, s.dict)
+
+	b, err := MarshalMessageJSON(msg, nil, nil, JSONOptions{})
+	s.Require().Nil(err)
+	s.JSONEq(`{
+		"Header": {"8": "FIX.4.4", "9": "23", "35": "D"},
+		"Body": {"11": "ID", "5000": "custom"},
+		"Trailer": {"10": "178"}
+	}`, string(b))
+}
+
+func (s *JSONSuite) TestRoundTrip() {
+	fixt, err := datadictionary.Parse("spec/FIXT11.xml")
+	s.Require().Nil(err)
+	fix50sp2, err := datadictionary.Parse("spec/FIX50SP2.xml")
+	s.Require().Nil(err)
+
+	var tests = []struct {
+		rawMsg                 string
+		transportDict, appDict *datadictionary.DataDictionary
+	}{
+		{"8=FIX.4.4|9=170|35=D|34=2|43=Y|49=TW|52=20230101-10:00:00.123|56=ISLD|11=ID|21=1|38=100|40=2|44=10.50|54=1|55=MSFT|60=20230101-10:00:00|453=2|448=P1|447=D|452=1|802=1|523=S1|803=1|448=P2|447=D|452=3|5000=custom|10=000|", s.dict, s.dict},
+		{"8=FIX.4.4|9=82|35=W|34=3|49=TW|56=ISLD|55=MSFT|262=MD|268=2|269=0|270=10.25|271=100|269=1|270=10.75|271=200|10=000|", s.dict, s.dict},
+		{"8=FIXT.1.1|9=95|35=8|34=4|49=TW|56=ISLD|1128=9|6=0|11=ID|14=0|17=E|37=O|39=0|54=1|55=MSFT|150=0|151=100|555=1|600=MSFT|624=1|10=000|", fixt, fix50sp2},
+	}
+
+	for _, test := range tests {
+		msg := s.parse(test.rawMsg, test.transportDict, test.appDict)
+
+		b, err := MarshalMessageJSON(msg, test.transportDict, test.appDict, JSONOptions{})
+		s.Require().Nil(err)
+
+		decoded := NewMessage()
+		s.Require().Nil(UnmarshalMessageJSON(decoded, b, test.transportDict, test.appDict))
+		s.Equal(msg.String(), decoded.String())
+
+		reencoded, err := MarshalMessageJSON(decoded, test.transportDict, test.appDict, JSONOptions{})
+		s.Require().Nil(err)
+		s.JSONEq(string(b), string(reencoded))
+	}
+}
+
+func (s *JSONSuite) TestUnmarshalStringValues() {
+	msg := NewMessage()
+	s.Require().Nil(UnmarshalMessageJSON(msg, []byte(`{
+		"Header": {"BeginString": "FIX.4.4", "MsgType": "D", "MsgSeqNum": "2", "PossDupFlag": "Y"},
+		"Body": {"ClOrdID": "ID", "Side": "BUY", "OrderQty": 100, "NoPartyIDs": [{"PartyID": "P1", "PartyRole": "1"}]}
+	}`), s.dict, s.dict))
+
+	s.Equal("8=FIX.4.4|9=52|35=D|34=2|43=Y|11=ID|38=100|54=1|453=1|448=P1|452=1|10=113|", strings.Replace(msg.String(), "\x01", "|", -1))
+}
+
+func (s *JSONSuite) TestUnmarshalErrors() {
+	var tests = []string{
+		`{"Header": {"NotAField": "1"}}`,
+		`{"Header": {"MsgType": "D"}, "Body": {"ClOrdID": ["ID"]}}`,
+		`{"Header": {"MsgType": "D"}, "Body": {"ClOrdID": {"ID": 1}}}`,
+		`{"Header": []}`,
+	}
+
+	for _, test := range tests {
+		s.NotNil(UnmarshalMessageJSON(NewMessage(), []byte(test), s.dict, s.dict), test)
+	}
+}
+
+func (s *JSONSuite) TestJSONMessage() {
+	msg := s.parse("8=FIX.4.4|9=40|35=D|11=ID|40=2|54=1|10=000|", s.dict, s.dict)
+
+	b, err := json.Marshal(struct {
+		Message JSONMessage
+	}{JSONMessage{Message: msg, TransportDataDictionary: s.dict, AppDataDictionary: s.dict}})
+	s.Require().Nil(err)
+
+	var decoded struct {
+		Message JSONMessage
+	}
+	decoded.Message.TransportDataDictionary = s.dict
+	decoded.Message.AppDataDictionary = s.dict
+	s.Require().Nil(json.Unmarshal(b, &decoded))
+	s.Equal(msg.String(), decoded.Message.String())
+}
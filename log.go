@@ -2,10 +2,10 @@ package quickfix
 
 //Log is a generic interface for logging FIX messages and events.
 type Log interface {
-	//log incoming fix message
+	//log incoming fix message, the bytes are a copy owned by the session and may be reused once the call returns
 	OnIncoming([]byte)
 
-	//log outgoing fix message
+	//log outgoing fix message, the bytes are a copy owned by the session and may be reused once the call returns
 	OnOutgoing([]byte)
 
 	//log fix event
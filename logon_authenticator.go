@@ -0,0 +1,69 @@
+package quickfix
+
+import (
+	"crypto/tls"
+	"errors"
+	"net"
+)
+
+//SessionStatus(1409) values reported to the counterparty when a logon is rejected.
+const (
+	SessionStatusInvalidUsernameOrPassword = 5
+	SessionStatusAccountLocked             = 6
+	SessionStatusLogonsNotAllowed          = 7
+	SessionStatusPasswordExpired           = 8
+)
+
+//LogonAuthenticator may optionally be implemented by an Application to validate logon requests received by an acceptor.
+//Authenticate is called before the session is marked as logged on and before FromAdmin. Returning a non-nil error rejects
+//the logon: a Logout is sent with the rejection Text(58) and SessionStatus(1409), and the connection is closed.
+type LogonAuthenticator interface {
+	//Authenticate validates the logon message, e.g. Username(553) and Password(554), the address of the remote peer and, for
+	//TLS connections, the negotiated connection state including any client certificates. tlsState is nil if TLS is not in use.
+	Authenticate(logon *Message, remoteAddr net.Addr, tlsState *tls.ConnectionState, sessionID SessionID) error
+}
+
+//LogonAuthenticationError is returned by a LogonAuthenticator to reject a logon with a specific SessionStatus(1409),
+//it may be wrapped. Any other error returned by Authenticate is reported with SessionStatusInvalidUsernameOrPassword.
+type LogonAuthenticationError struct {
+	Text          string
+	SessionStatus int
+}
+
+func (e LogonAuthenticationError) Error() string { return e.Text }
+
+//authenticateLogon consults the session authenticator, if any, for the logon received on the current connection.
+//A rejected logon is reported as a LogonAuthenticationError.
+func (s *session) authenticateLogon(logon *Message) error {
+	if s.authenticator == nil {
+		return nil
+	}
+
+	err := s.authenticator.Authenticate(logon, s.remoteAddr, s.tlsState, s.sessionID)
+	if err == nil {
+		return nil
+	}
+
+	var authErr LogonAuthenticationError
+	if errors.As(err, &authErr) {
+		return authErr
+	}
+
+	var authErrPtr *LogonAuthenticationError
+	if errors.As(err, &authErrPtr) && authErrPtr != nil {
+		return *authErrPtr
+	}
+
+	return LogonAuthenticationError{Text: err.Error(), SessionStatus: SessionStatusInvalidUsernameOrPassword}
+}
+
+//connectionState returns the TLS connection state of netConn, or nil if the connection is not using TLS.
+func connectionState(netConn net.Conn) *tls.ConnectionState {
+	tlsConn, ok := netConn.(*tls.Conn)
+	if !ok {
+		return nil
+	}
+
+	state := tlsConn.ConnectionState()
+	return &state
+}
@@ -2,6 +2,7 @@ package quickfix
 
 import (
 	"bytes"
+	"errors"
 
 	"github.com/quickfixgo/quickfix/internal"
 )
@@ -22,22 +23,30 @@ func (s logonState) FixMsgIn(session *session, msg *Message) (nextState sessionS
 	}
 
 	if err := session.handleLogon(msg); err != nil {
+		var authErr LogonAuthenticationError
+		if errors.As(err, &authErr) {
+			session.log.OnEventf("Logon authentication failed: %v", authErr.Text)
+			return s.rejectLogon(session, msg, authErr.Text, authErr.SessionStatus)
+		}
+
 		switch err := err.(type) {
 		case RejectLogon:
 			session.log.OnEvent(err.Text)
-			logout := session.buildLogout(err.Text)
+			return s.rejectLogon(session, msg, err.Text, 0)
 
-			if err := session.dropAndSendInReplyTo(logout, msg); err != nil {
-				session.logError(err)
-			}
+		case targetTooHigh:
+			if session.recoversWithNextExpectedMsgSeqNum(msg) {
+				session.log.OnEventf("MsgSeqNum too high, expecting %v but received %v, awaiting resend for NextExpectedMsgSeqNum", err.ExpectedTarget, err.ReceivedTarget)
 
-			if err := session.store.IncrNextTargetMsgSeqNum(); err != nil {
-				session.logError(err)
+				//the logon is counted once the messages before it are recovered
+				msg.Retain()
+				session.resetResendTimer()
+				return resendState{
+					messageStash:   map[int]*Message{err.ReceivedTarget: msg},
+					resendRangeEnd: err.ReceivedTarget - 1,
+				}
 			}
 
-			return latentState{}
-
-		case targetTooHigh:
 			var tooHighErr error
 			if nextState, tooHighErr = session.doTargetTooHigh(err); tooHighErr != nil {
 				return handleStateError(session, tooHighErr)
@@ -52,6 +61,25 @@ func (s logonState) FixMsgIn(session *session, msg *Message) (nextState sessionS
 	return inSession{}
 }
 
+//rejectLogon replies to a rejected logon with a Logout carrying text and, if not zero, sessionStatus, then drops the
+//connection
+func (s logonState) rejectLogon(session *session, logon *Message, text string, sessionStatus int) (nextState sessionState) {
+	logout := session.buildLogout(text)
+	if sessionStatus != 0 {
+		logout.Body.SetField(tagSessionStatus, FIXInt(sessionStatus))
+	}
+
+	if err := session.dropAndSendInReplyTo(logout, logon); err != nil {
+		session.logError(err)
+	}
+
+	if err := session.store.IncrNextTargetMsgSeqNum(); err != nil {
+		session.logError(err)
+	}
+
+	return latentState{}
+}
+
 func (s logonState) Timeout(session *session, e internal.Event) (nextState sessionState) {
 	switch e {
 	case internal.LogonTimeout:
@@ -2,6 +2,10 @@ package quickfix
 
 import (
 	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
 	"testing"
 	"time"
 
@@ -245,6 +249,7 @@ func (s *LogonStateTestSuite) TestFixMsgInLogonRejectLogon() {
 	s.MessageFactory.seqNum = 1
 	s.IncrNextTargetMsgSeqNum()
 
+	s.session.HeartBtInt = 30 * time.Second
 	logon := s.Logon()
 	logon.Body.SetField(tagHeartBtInt, FIXInt(32))
 
@@ -259,11 +264,101 @@ func (s *LogonStateTestSuite) TestFixMsgInLogonRejectLogon() {
 	s.LastToAdminMessageSent()
 	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
 	s.FieldEquals(tagText, "reject message", s.MockApp.lastToAdmin.Body)
+	s.False(s.MockApp.lastToAdmin.Body.Has(tagSessionStatus))
+	s.Equal(30*time.Second, s.session.HeartBtInt, "rejected logon should not change HeartBtInt")
 
 	s.NextTargetMsgSeqNum(3)
 	s.NextSenderMsgSeqNum(3)
 }
 
+type mockAuthenticator struct {
+	err        error
+	remoteAddr net.Addr
+	logon      *Message
+}
+
+func (a *mockAuthenticator) Authenticate(logon *Message, remoteAddr net.Addr, tlsState *tls.ConnectionState, sessionID SessionID) error {
+	a.logon = logon
+	a.remoteAddr = remoteAddr
+	return a.err
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonAuthenticated() {
+	auth := &mockAuthenticator{}
+	s.session.authenticator = auth
+	s.session.remoteAddr = &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5001}
+
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(32))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("OnLogon")
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, logon)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(inSession{})
+	s.Equal(logon, auth.logon)
+	s.Equal("10.0.0.1:5001", auth.remoteAddr.String())
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonAuthenticationRejected() {
+	var tests = []struct {
+		err            error
+		expectedStatus int
+	}{
+		{LogonAuthenticationError{Text: "account locked", SessionStatus: SessionStatusAccountLocked}, SessionStatusAccountLocked},
+		{&LogonAuthenticationError{Text: "password expired", SessionStatus: SessionStatusPasswordExpired}, SessionStatusPasswordExpired},
+		{fmt.Errorf("ldap: %w", LogonAuthenticationError{Text: "logons not allowed", SessionStatus: SessionStatusLogonsNotAllowed}), SessionStatusLogonsNotAllowed},
+		{errors.New("bad password"), SessionStatusInvalidUsernameOrPassword},
+	}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.session.authenticator = &mockAuthenticator{err: test.err}
+
+		expectedText := test.err.Error()
+		var authErr LogonAuthenticationError
+		if errors.As(test.err, &authErr) {
+			expectedText = authErr.Text
+		}
+
+		logon := s.Logon()
+		logon.Body.SetField(tagHeartBtInt, FIXInt(32))
+
+		s.MockApp.On("ToAdmin")
+		s.fixMsgIn(s.session, logon)
+
+		s.MockApp.AssertNotCalled(s.T(), "FromAdmin")
+		s.MockApp.AssertNotCalled(s.T(), "OnLogon")
+		s.State(latentState{})
+
+		s.LastToAdminMessageSent()
+		s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+		s.FieldEquals(tagText, expectedText, s.MockApp.lastToAdmin.Body)
+		s.FieldEquals(tagSessionStatus, test.expectedStatus, s.MockApp.lastToAdmin.Body)
+
+		s.NextTargetMsgSeqNum(2)
+		s.NextSenderMsgSeqNum(2)
+	}
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonInitiateLogonSkipsAuthentication() {
+	s.session.InitiateLogon = true
+	s.session.authenticator = &mockAuthenticator{err: errors.New("should not be called")}
+	s.IncrNextSenderMsgSeqNum()
+
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(32))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("OnLogon")
+	s.fixMsgIn(s.session, logon)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(inSession{})
+}
+
 func (s *LogonStateTestSuite) TestFixMsgInLogonSeqNumTooHigh() {
 	s.MessageFactory.SetNextSeqNum(6)
 	logon := s.Logon()
@@ -302,3 +397,147 @@ func (s *LogonStateTestSuite) TestFixMsgInLogonSeqNumTooHigh() {
 	s.State(inSession{})
 	s.NextTargetMsgSeqNum(7)
 }
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonHeartBtIntOutOfBounds() {
+	var tests = []struct {
+		heartBtInt   int
+		expectedText string
+	}{
+		{-1, "HeartBtInt -1 must not be negative"},
+		{5, "HeartBtInt 5 is less than the minimum of 10"},
+		{61, "HeartBtInt 61 is greater than the maximum of 60"},
+	}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.session.MinHeartBtInt = 10 * time.Second
+		s.session.MaxHeartBtInt = 60 * time.Second
+
+		logon := s.Logon()
+		logon.Body.SetField(tagHeartBtInt, FIXInt(test.heartBtInt))
+
+		s.MockApp.On("ToAdmin")
+		s.fixMsgIn(s.session, logon)
+
+		s.MockApp.AssertNotCalled(s.T(), "FromAdmin")
+		s.MockApp.AssertNotCalled(s.T(), "OnLogon")
+		s.State(latentState{})
+
+		s.LastToAdminMessageSent()
+		s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+		s.FieldEquals(tagText, test.expectedText, s.MockApp.lastToAdmin.Body)
+	}
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonHeartBtIntWithinBounds() {
+	s.session.MinHeartBtInt = 10 * time.Second
+	s.session.MaxHeartBtInt = 60 * time.Second
+
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(60))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("OnLogon")
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, logon)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(inSession{})
+	s.Equal(60*time.Second, s.session.HeartBtInt)
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonHeartBtIntZero() {
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(0))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("OnLogon")
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, logon)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(inSession{})
+	s.Equal(time.Duration(0), s.session.HeartBtInt)
+
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeLogon), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagHeartBtInt, 0, s.MockApp.lastToAdmin.Body)
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonNextExpectedMsgSeqNum() {
+	s.session.EnableNextExpectedMsgSeqNum = true
+
+	s.MockApp.On("ToApp").Return(nil)
+	s.Require().Nil(s.session.send(s.NewOrderSingle()))
+	s.NextSenderMsgSeqNum(2)
+
+	s.MessageFactory.SetNextSeqNum(1)
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(32))
+	logon.Body.SetField(tagNextExpectedMsgSeqNum, FIXInt(1))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("OnLogon")
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, logon)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(inSession{})
+
+	s.MessageType(string(msgTypeLogon), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagMsgSeqNum, 2, s.MockApp.lastToAdmin.Header)
+	s.FieldEquals(tagNextExpectedMsgSeqNum, 2, s.MockApp.lastToAdmin.Body)
+
+	s.MockApp.AssertNumberOfCalls(s.T(), "ToApp", 2)
+	s.MessageType("D", s.MockApp.lastToApp)
+	s.FieldEquals(tagMsgSeqNum, 1, s.MockApp.lastToApp.Header)
+	s.FieldEquals(tagPossDupFlag, true, s.MockApp.lastToApp.Header)
+
+	s.NextTargetMsgSeqNum(2)
+	s.NextSenderMsgSeqNum(3)
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonNextExpectedMsgSeqNumTooHigh() {
+	s.session.EnableNextExpectedMsgSeqNum = true
+
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(32))
+	logon.Body.SetField(tagNextExpectedMsgSeqNum, FIXInt(5))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, logon)
+
+	s.MockApp.AssertNotCalled(s.T(), "OnLogon")
+	s.State(latentState{})
+
+	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagText, "NextExpectedMsgSeqNum too high, expecting 1 but received 5", s.MockApp.lastToAdmin.Body)
+}
+
+func (s *LogonStateTestSuite) TestFixMsgInLogonNextExpectedMsgSeqNumSeqNumTooHigh() {
+	s.session.EnableNextExpectedMsgSeqNum = true
+
+	s.MessageFactory.SetNextSeqNum(6)
+	logon := s.Logon()
+	logon.Body.SetField(tagHeartBtInt, FIXInt(32))
+	logon.Body.SetField(tagNextExpectedMsgSeqNum, FIXInt(1))
+
+	s.MockApp.On("FromAdmin").Return(nil)
+	s.MockApp.On("OnLogon")
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, logon)
+
+	s.State(resendState{})
+	s.NextTargetMsgSeqNum(1)
+
+	//only the logon response is sent, the counterparty resends without a ResendRequest
+	s.MockApp.AssertNumberOfCalls(s.T(), "ToAdmin", 1)
+	s.MessageType(string(msgTypeLogon), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagNextExpectedMsgSeqNum, 1, s.MockApp.lastToAdmin.Body)
+
+	s.MessageFactory.SetNextSeqNum(1)
+	s.fixMsgIn(s.session, s.SequenceReset(6))
+	s.State(inSession{})
+	s.NextTargetMsgSeqNum(7)
+}
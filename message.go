@@ -57,13 +57,17 @@ func (b *Body) Init() {
 // Trailer is the last section of a FIX message
 type Trailer struct{ FieldMap }
 
-// In the trailer, CheckSum (tag 10) must be last
+// In the trailer, CheckSum (tag 10) must be last, and SignatureLength (tag 93) must precede Signature (tag 89)
 func trailerFieldOrdering(i, j Tag) bool {
 	switch {
 	case i == tagCheckSum:
 		return false
 	case j == tagCheckSum:
 		return true
+	case i == tagSignatureLength && j == tagSignature:
+		return true
+	case i == tagSignature && j == tagSignatureLength:
+		return false
 	}
 
 	return i < j
@@ -91,8 +95,11 @@ type Message struct {
 	//field bytes as they appear in the raw message
 	fields []TagValue
 
-	//flag is true if this message should not be returned to pool after use
-	keepMessage bool
+	//references held on a pooled message, see Retain and Release
+	refs int32
+
+	//flag is true if this message was taken from the message pool
+	pooled bool
 }
 
 // ToMessage returns the message itself
@@ -194,15 +201,27 @@ func ParseMessageWithDataDictionary(
 	msg.Header.add(msg.fields[fieldIndex : fieldIndex+1])
 	fieldIndex++
 
+	dicts := dataDictionaries{transportDataDictionary, applicationDataDictionary}
+	var lengthField *TagValue
+
 	trailerBytes := []byte{}
 	foundBody := false
 	for {
 		parsedFieldBytes = &msg.fields[fieldIndex]
-		rawBytes, err = extractField(parsedFieldBytes, rawBytes)
+		if dataLength, ok := dataFieldLength(lengthField, rawBytes, dicts); ok {
+			rawBytes, err = extractDataField(parsedFieldBytes, rawBytes, dataLength)
+		} else {
+			rawBytes, err = extractField(parsedFieldBytes, rawBytes)
+		}
 		if err != nil {
 			return
 		}
 
+		lengthField = nil
+		if dicts.isLengthField(parsedFieldBytes.tag) {
+			lengthField = parsedFieldBytes
+		}
+
 		switch {
 		case isHeaderField(parsedFieldBytes.tag, transportDataDictionary):
 			msg.Header.add(msg.fields[fieldIndex : fieldIndex+1])
@@ -224,6 +243,11 @@ func ParseMessageWithDataDictionary(
 		fieldIndex++
 	}
 
+	//data fields may contain SOH, fewer fields than counted
+	msg.fields = msg.fields[:fieldIndex+1]
+
+	msg.parseRepeatingGroups(dicts)
+
 	//body length would only be larger than trailer if fields out of order
 	if len(msg.bodyBytes) > len(trailerBytes) {
 		msg.bodyBytes = msg.bodyBytes[:len(msg.bodyBytes)-len(trailerBytes)]
@@ -361,24 +385,35 @@ func (m *Message) String() string {
 	return string(msg)
 }
 
-func formatCheckSum(value int) string {
-	return fmt.Sprintf("%03d", value)
+//appendCheckSum appends the three digit checksum value to b
+func appendCheckSum(b []byte, value int) []byte {
+	return append(b, byte('0'+value/100%10), byte('0'+value/10%10), byte('0'+value%10))
 }
 
 // Build constructs a []byte from a Message instance
 func (m *Message) build() []byte {
+	bodyLength := m.cook()
+	return m.appendTo(make([]byte, 0, bodyLength+64))
+}
+
+//buildTo constructs the message appended to b, allocation free when b has capacity for the message
+func (m *Message) buildTo(b []byte) []byte {
 	m.cook()
+	return m.appendTo(b)
+}
 
-	var b bytes.Buffer
-	m.Header.write(&b)
-	m.Body.write(&b)
-	m.Trailer.write(&b)
-	return b.Bytes()
+func (m *Message) appendTo(b []byte) []byte {
+	b = m.Header.appendTo(b)
+	b = m.Body.appendTo(b)
+	return m.Trailer.appendTo(b)
 }
 
-func (m *Message) cook() {
-	bodyLength := m.Header.length() + m.Body.length() + m.Trailer.length()
+func (m *Message) cook() (bodyLength int) {
+	bodyLength = m.Header.length() + m.Body.length() + m.Trailer.length()
 	m.Header.SetInt(tagBodyLength, bodyLength)
 	checkSum := (m.Header.total() + m.Body.total() + m.Trailer.total()) % 256
-	m.Trailer.SetString(tagCheckSum, formatCheckSum(checkSum))
+
+	var b [3]byte
+	m.Trailer.SetBytes(tagCheckSum, appendCheckSum(b[:0], checkSum))
+	return
 }
@@ -0,0 +1,89 @@
+package quickfix
+
+import "github.com/quickfixgo/quickfix/datadictionary"
+
+//fieldDefs returns the field definitions at one level of a message, a MessageDef or the group FieldDef
+type fieldDefs func(tag Tag) *datadictionary.FieldDef
+
+//messageDefs returns the field definitions of the header, body and trailer of msgType, none without a DataDictionary
+func (d dataDictionaries) messageDefs(msgType string) (header, body, trailer fieldDefs) {
+	none := func(Tag) *datadictionary.FieldDef { return nil }
+	header, body, trailer = none, none, none
+
+	transportDict, appDict := d[0], d[1]
+	if transportDict == nil {
+		return
+	}
+
+	header = messageDefFields(transportDict.Header)
+	trailer = messageDefFields(transportDict.Trailer)
+	if messageDef, ok := appDict.Messages[msgType]; ok {
+		body = messageDefFields(messageDef)
+	}
+
+	return
+}
+
+//messageDefFields returns the field definitions of a header, trailer or message body
+func messageDefFields(messageDef *datadictionary.MessageDef) fieldDefs {
+	return func(tag Tag) *datadictionary.FieldDef { return messageDef.Fields[int(tag)] }
+}
+
+//groupDefFields returns the field definitions of an instance of a repeating group
+func groupDefFields(groupDef *datadictionary.FieldDef) fieldDefs {
+	return func(tag Tag) *datadictionary.FieldDef {
+		for _, child := range groupDef.Fields {
+			if Tag(child.Tag()) == tag {
+				return child
+			}
+		}
+		return nil
+	}
+}
+
+//fieldType returns the type of tag from either DataDictionary
+func (d dataDictionaries) fieldType(tag Tag) *datadictionary.FieldType {
+	for _, dict := range d {
+		if dict == nil {
+			continue
+		}
+
+		if fieldType, ok := dict.FieldTypeByTag[int(tag)]; ok {
+			return fieldType
+		}
+	}
+
+	return nil
+}
+
+//messageFields returns the header, body and trailer fields of msg, including the fields of repeating groups. Parsed
+//messages keep the order fields were received, as groups parsed without a DataDictionary are stored by tag. Built
+//messages are in field order.
+func (d dataDictionaries) messageFields(msg *Message) (header, body, trailer []TagValue) {
+	if msg.rawMessage == nil {
+		return sortedFields(&msg.Header.FieldMap), sortedFields(&msg.Body.FieldMap), sortedFields(&msg.Trailer.FieldMap)
+	}
+
+	for _, tv := range msg.fields {
+		switch {
+		case isHeaderField(tv.tag, d[0]):
+			header = append(header, tv)
+		case isTrailerField(tv.tag, d[0]):
+			trailer = append(trailer, tv)
+		default:
+			body = append(body, tv)
+		}
+	}
+
+	return
+}
+
+//sortedFields returns the fields of m in field order, including the fields of its repeating groups
+func sortedFields(m *FieldMap) []TagValue {
+	var tvs []TagValue
+	for _, tag := range m.sortedTags() {
+		tvs = append(tvs, m.tagLookup[tag]...)
+	}
+
+	return tvs
+}
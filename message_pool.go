@@ -1,26 +1,105 @@
 package quickfix
 
+import (
+	"sync"
+	"sync/atomic"
+)
+
+//maxPooledMessageFields caps the number of parsed fields a message may hold and still be pooled,
+//unusually large messages are left to the garbage collector
+const maxPooledMessageFields = 1024
+
+//messagePool is a concurrently safe pool of parsed messages shared by all sessions
 type messagePool struct {
-	m []*Message
+	pool sync.Pool
 }
 
-func (p *messagePool) New() *Message {
-	msg := NewMessage()
+var sharedMessagePool messagePool
+
+//Get returns a message from the pool holding a single reference
+func (p *messagePool) Get() *Message {
+	msg, ok := p.pool.Get().(*Message)
+	if !ok {
+		msg = NewMessage()
+	}
+
+	msg.pooled = true
+	msg.refs = 1
+
 	return msg
 }
 
-func (p *messagePool) Get() (msg *Message) {
-	if len(p.m) > 0 {
-		msg, p.m = p.m[len(p.m)-1], p.m[:len(p.m)-1]
-	} else {
-		msg = p.New()
+//Put returns the message and its raw bytes to their pools
+func (p *messagePool) Put(msg *Message) {
+	if msg.rawMessage != nil {
+		bufferPool.Put(msg.rawMessage)
+		msg.rawMessage = nil
 	}
 
-	msg.keepMessage = false
+	if cap(msg.fields) > maxPooledMessageFields {
+		return
+	}
 
-	return
+	msg.pooled = false
+	p.pool.Put(msg)
 }
 
-func (p *messagePool) Put(msg *Message) {
-	p.m = append(p.m, msg)
+// Retain adds a reference to a message received by the engine, keeping it and its raw bytes from being
+// reused once the callback it was passed to returns.
+//
+// Messages passed to Application.FromAdmin and Application.FromApp are owned by the session and are only
+// valid for the duration of the call. An application holding on to such a message, for example to
+// process it on another goroutine, must call Retain before returning and Release when done with it.
+// Retain and Release have no effect on messages created with NewMessage.
+func (m *Message) Retain() {
+	if m.pooled {
+		atomic.AddInt32(&m.refs, 1)
+	}
+}
+
+// Release drops a reference added by Retain. Once the last reference is released the message is
+// returned to the pool and must no longer be used.
+func (m *Message) Release() {
+	if !m.pooled {
+		return
+	}
+
+	switch refs := atomic.AddInt32(&m.refs, -1); {
+	case refs == 0:
+		sharedMessagePool.Put(m)
+	case refs < 0:
+		panic("quickfix: Message released more times than retained")
+	}
+}
+
+//maxPooledOutboundBuffers caps the number of written message buffers kept for reuse
+const maxPooledOutboundBuffers = 256
+
+//maxPooledOutboundBufferSize leaves buffers of unusually large outgoing messages to the garbage collector
+const maxPooledOutboundBufferSize = 64 * 1024
+
+//outboundBuffers recycles the buffers outgoing messages are built into, they are handed back by the write loop once
+//written. A buffered channel is used rather than a sync.Pool as storing a slice in a sync.Pool allocates.
+var outboundBuffers = make(chan []byte, maxPooledOutboundBuffers)
+
+//getOutboundBuffer returns an empty buffer to build an outgoing message into
+func getOutboundBuffer() []byte {
+	select {
+	case b := <-outboundBuffers:
+		return b
+	default:
+		return make([]byte, 0, 512)
+	}
+}
+
+//putOutboundBuffer recycles the buffer of a written message, it must no longer be used by the caller
+func putOutboundBuffer(b []byte) {
+	if cap(b) > maxPooledOutboundBufferSize {
+		return
+	}
+
+	select {
+	case outboundBuffers <- b[:0]:
+	default:
+	}
 }
@@ -0,0 +1,172 @@
+package quickfix
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestMessagePoolRetainRelease(t *testing.T) {
+	raw := bufferPool.Get()
+	raw.WriteString("8=FIX.4.2\x019=49\x0135=0\x0134=1\x0149=TW\x0152=20160801-12:00:00.000\x0156=ISLD\x0110=153\x01")
+
+	msg := sharedMessagePool.Get()
+	require.Nil(t, ParseMessage(msg, raw))
+
+	msg.Retain()
+	msg.Release()
+	assert.Equal(t, raw, msg.rawMessage, "a retained message keeps its raw bytes")
+
+	msg.Release()
+	assert.Nil(t, msg.rawMessage, "raw bytes are returned to the pool with the last release")
+}
+
+func TestMessagePoolUnpooledMessage(t *testing.T) {
+	msg := NewMessage()
+	msg.Header.SetField(tagMsgType, FIXString("D"))
+
+	assert.NotPanics(t, func() {
+		msg.Retain()
+		msg.Release()
+		msg.Release()
+	})
+
+	msgType, err := msg.MsgType()
+	assert.Nil(t, err)
+	assert.Equal(t, "D", msgType)
+}
+
+func TestMessagePoolConcurrentRetain(t *testing.T) {
+	var factory MessageFactory
+	raws := make([][]byte, 100)
+	for i := range raws {
+		raws[i] = factory.NewOrderSingle().build()
+	}
+
+	retained := make(chan *Message, len(raws))
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < len(raws); i++ {
+			msg := <-retained
+			seqNum, err := msg.Header.GetInt(tagMsgSeqNum)
+			assert.Nil(t, err)
+			assert.Equal(t, string(raws[seqNum-1]), string(msg.rawMessage.Bytes()))
+			msg.Release()
+		}
+	}()
+
+	for _, raw := range raws {
+		buf := bufferPool.Get()
+		buf.Write(raw)
+
+		msg := sharedMessagePool.Get()
+		require.Nil(t, ParseMessage(msg, buf))
+
+		//hand off to another goroutine, as an application would from FromApp
+		msg.Retain()
+		retained <- msg
+		msg.Release()
+	}
+
+	wg.Wait()
+}
+
+type MessagePoolTestSuite struct {
+	SessionSuiteRig
+}
+
+func TestMessagePoolTestSuite(t *testing.T) {
+	suite.Run(t, new(MessagePoolTestSuite))
+}
+
+func (s *MessagePoolTestSuite) SetupTest() {
+	s.Init()
+	s.session.State = inSession{}
+}
+
+//retainingApp keeps every application message received, as allowed by Message.Retain
+type retainingApp struct {
+	*MockApp
+	retained chan *Message
+}
+
+func (a retainingApp) FromApp(msg *Message, sessionID SessionID) MessageRejectError {
+	msg.Retain()
+	a.retained <- msg
+	return nil
+}
+
+//copyingLog records a copy of every logged message, checking the logged bytes are not modified while logged
+type copyingLog struct {
+	nullLog
+	incoming, outgoing [][]byte
+}
+
+func (l *copyingLog) OnIncoming(msg []byte) { l.incoming = append(l.incoming, append([]byte{}, msg...)) }
+func (l *copyingLog) OnOutgoing(msg []byte) { l.outgoing = append(l.outgoing, append([]byte{}, msg...)) }
+
+func (s *MessagePoolTestSuite) TestRetainedMessagesAreNotReused() {
+	app := retainingApp{MockApp: &s.MockApp, retained: make(chan *Message)}
+	log := new(copyingLog)
+	s.session.application = app
+	s.session.log = log
+
+	var sent [][]byte
+	for i := 0; i < 50; i++ {
+		sent = append(sent, s.NewOrderSingle().build())
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range sent {
+			msg := <-app.retained
+			s.Equal(string(sent[i]), string(msg.rawMessage.Bytes()))
+			msg.Release()
+		}
+	}()
+
+	for _, raw := range sent {
+		buf := bufferPool.Get()
+		buf.Write(raw)
+		s.session.Incoming(s.session, fixIn{bytes: buf})
+	}
+
+	wg.Wait()
+	s.Require().Len(log.incoming, len(sent))
+	for i := range sent {
+		s.Equal(string(sent[i]), string(log.incoming[i]))
+	}
+	s.NextTargetMsgSeqNum(len(sent) + 1)
+}
+
+func (s *MessagePoolTestSuite) TestStoredMessagesAreNotReused() {
+	s.MockApp.On("ToApp").Return(nil)
+
+	var sent [][]byte
+	for i := 0; i < 20; i++ {
+		s.Require().Nil(s.session.send(s.NewOrderSingle()))
+		msg, _ := s.Receiver.LastMessage()
+		s.Require().NotNil(msg)
+		sent = append(sent, append([]byte{}, msg...))
+
+		//inbound traffic cycling pooled buffers must not touch persisted messages
+		buf := bufferPool.Get()
+		buf.Write(bytes.Repeat([]byte{'x'}, len(msg)))
+		bufferPool.Put(buf)
+	}
+
+	stored, err := s.MockStore.GetMessages(1, len(sent))
+	s.Require().Nil(err)
+	s.Require().Len(stored, len(sent))
+	for i := range sent {
+		s.Equal(string(sent[i]), string(stored[i]))
+	}
+}
@@ -10,11 +10,136 @@ import (
 )
 
 func BenchmarkParseMessage(b *testing.B) {
-	rawMsg := bytes.NewBufferString("8=FIX.4.29=10435=D34=249=TW52=20140515-19:49:56.65956=ISLD11=10021=140=154=155=TSLA60=00010101-00:00:00.00010=039")
+	rawMsg := bytes.NewBufferString("8=FIX.4.29=10435=D34=249=TW52=20140515-19:49:56.65956=ISLD11=10021=140=154=155=TSLA60=00010101-00:00:00.00010=039")
 
-	var msg Message
+	msg := NewMessage()
 	for i := 0; i < b.N; i++ {
-		_ = ParseMessage(&msg, rawMsg)
+		_ = ParseMessage(msg, rawMsg)
+	}
+}
+
+func setBenchmarkHeader(msg *Message, msgType string) {
+	msg.Header.SetString(tagBeginString, BeginStringFIX44)
+	msg.Header.SetString(tagMsgType, msgType)
+	msg.Header.SetInt(tagMsgSeqNum, 1024)
+	msg.Header.SetString(tagSenderCompID, "TW")
+	msg.Header.SetString(tagTargetCompID, "ISLD")
+	msg.Header.SetString(tagSendingTime, "20140515-19:49:56.659")
+}
+
+func setNewOrderSingle(msg *Message) {
+	setBenchmarkHeader(msg, "D")
+	msg.Body.SetString(Tag(11), "ORD-1024")
+	msg.Body.SetString(Tag(21), "1")
+	msg.Body.SetString(Tag(55), "TSLA")
+	msg.Body.SetString(Tag(54), "1")
+	msg.Body.SetString(Tag(60), "20140515-19:49:56.659")
+	msg.Body.SetInt(Tag(38), 100)
+	msg.Body.SetString(Tag(40), "2")
+	msg.Body.SetString(Tag(44), "245.25")
+}
+
+func setExecutionReport(msg *Message) {
+	setBenchmarkHeader(msg, "8")
+	msg.Body.SetString(Tag(37), "EX-2048")
+	msg.Body.SetString(Tag(11), "ORD-1024")
+	msg.Body.SetString(Tag(17), "EXEC-4096")
+	msg.Body.SetString(Tag(150), "F")
+	msg.Body.SetString(Tag(39), "2")
+	msg.Body.SetString(Tag(55), "TSLA")
+	msg.Body.SetString(Tag(54), "1")
+	msg.Body.SetInt(Tag(151), 0)
+	msg.Body.SetInt(Tag(14), 100)
+	msg.Body.SetString(Tag(6), "245.25")
+	msg.Body.SetInt(Tag(32), 100)
+	msg.Body.SetString(Tag(31), "245.25")
+	msg.Body.SetString(Tag(60), "20140515-19:49:56.659")
+}
+
+//mdEntries is reused by each call to setMarketDataIncrementalRefresh
+var mdEntries = NewRepeatingGroup(Tag(268), GroupTemplate{
+	GroupElement(279), GroupElement(269), GroupElement(55), GroupElement(270), GroupElement(271),
+})
+
+func setMarketDataIncrementalRefresh(msg *Message) {
+	setBenchmarkHeader(msg, "X")
+	entries := mdEntries
+	entries.Clear()
+	for _, price := range []string{"245.25", "245.50", "245.75"} {
+		entries.Add().
+			SetString(Tag(279), "0").
+			SetString(Tag(269), "0").
+			SetString(Tag(55), "TSLA").
+			SetString(Tag(270), price).
+			SetInt(Tag(271), 500)
+	}
+	msg.Body.SetGroup(entries)
+}
+
+var benchmarkMessages = []struct {
+	name  string
+	setup func(*Message)
+}{
+	{"NewOrderSingle", setNewOrderSingle},
+	{"ExecutionReport", setExecutionReport},
+	{"MarketDataIncrementalRefresh", setMarketDataIncrementalRefresh},
+}
+
+func benchmarkRawMessage(setup func(*Message)) *bytes.Buffer {
+	msg := NewMessage()
+	setup(msg)
+	return bytes.NewBuffer(msg.build())
+}
+
+func clearMessage(msg *Message) {
+	msg.Header.Clear()
+	msg.Body.Clear()
+	msg.Trailer.Clear()
+}
+
+func BenchmarkMessage_Parse(b *testing.B) {
+	for _, bm := range benchmarkMessages {
+		rawMsg := benchmarkRawMessage(bm.setup)
+		b.Run(bm.name, func(b *testing.B) {
+			msg := NewMessage()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = ParseMessage(msg, rawMsg)
+			}
+		})
+	}
+}
+
+func BenchmarkMessage_ParseWithDataDictionary(b *testing.B) {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, bm := range benchmarkMessages {
+		rawMsg := benchmarkRawMessage(bm.setup)
+		b.Run(bm.name, func(b *testing.B) {
+			msg := NewMessage()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = ParseMessageWithDataDictionary(msg, rawMsg, dict, dict)
+			}
+		})
+	}
+}
+
+func BenchmarkMessage_Build(b *testing.B) {
+	for _, bm := range benchmarkMessages {
+		b.Run(bm.name, func(b *testing.B) {
+			msg := NewMessage()
+			var buffer []byte
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				clearMessage(msg)
+				bm.setup(msg)
+				buffer = msg.buildTo(buffer[:0])
+			}
+		})
 	}
 }
 
@@ -31,6 +156,38 @@ func (s *MessageSuite) SetupTest() {
 	s.msg = NewMessage()
 }
 
+func (s *MessageSuite) TestParseAllocationFree() {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	s.Require().Nil(err)
+
+	for _, bm := range benchmarkMessages {
+		rawMsg := benchmarkRawMessage(bm.setup)
+		allocs := testing.AllocsPerRun(100, func() {
+			s.Require().Nil(ParseMessage(s.msg, rawMsg))
+		})
+		s.Zero(allocs, "parsing %v", bm.name)
+
+		allocs = testing.AllocsPerRun(100, func() {
+			s.Require().Nil(ParseMessageWithDataDictionary(s.msg, rawMsg, dict, dict))
+		})
+		s.Zero(allocs, "parsing %v with DataDictionary", bm.name)
+	}
+}
+
+func (s *MessageSuite) TestBuildAllocationFree() {
+	var buffer []byte
+	for _, bm := range benchmarkMessages {
+		expected := benchmarkRawMessage(bm.setup).Bytes()
+		allocs := testing.AllocsPerRun(100, func() {
+			clearMessage(s.msg)
+			bm.setup(s.msg)
+			buffer = s.msg.buildTo(buffer[:0])
+		})
+		s.Zero(allocs, "building %v", bm.name)
+		s.Equal(string(expected), string(buffer))
+	}
+}
+
 func (s *MessageSuite) TestParseMessageEmpty() {
 	rawMsg := bytes.NewBufferString("")
 
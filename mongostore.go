@@ -42,7 +42,7 @@ func NewMongoStoreFactoryPrefixed(settings *Settings, collectionsPrefix string)
 
 // Create creates a new MongoStore implementation of the MessageStore interface
 func (f mongoStoreFactory) Create(sessionID SessionID) (msgStore MessageStore, err error) {
-	sessionSettings, ok := f.settings.SessionSettings()[sessionID]
+	sessionSettings, ok := f.settings.sessionSettingsFor(sessionID)
 	if !ok {
 		return nil, fmt.Errorf("unknown session: %v", sessionID)
 	}
@@ -215,6 +215,10 @@ func (store *mongoStore) CreationTime() time.Time {
 	return store.cache.CreationTime()
 }
 
+func (store *mongoStore) setClock(clock Clock) {
+	store.cache.setClock(clock)
+}
+
 func (store *mongoStore) SaveMessage(seqNum int, msg []byte) (err error) {
 	msgFilter := generateMessageFilter(&store.sessionID)
 	msgFilter.Msgseq = seqNum
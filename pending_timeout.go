@@ -1,16 +1,50 @@
 package quickfix
 
-import "github.com/quickfixgo/quickfix/internal"
+import (
+	"bytes"
+
+	"github.com/quickfixgo/quickfix/internal"
+)
 
 type pendingTimeout struct {
 	sessionState
 }
 
+//FixMsgIn ends the timeout unless msg is a Heartbeat that does not reply to an outstanding test request.
+func (s pendingTimeout) FixMsgIn(session *session, msg *Message) (nextState sessionState) {
+	msgType, _ := msg.Header.GetBytes(tagMsgType)
+	unanswered := bytes.Equal(msgTypeHeartbeat, msgType) && !session.isTestRequestReply(msg)
+
+	nextState = s.sessionState.FixMsgIn(session, msg)
+	if !unanswered {
+		return
+	}
+
+	switch nextState.(type) {
+	case inSession, resendState:
+		return pendingTimeout{nextState}
+	}
+
+	return
+}
+
 func (s pendingTimeout) Timeout(session *session, event internal.Event) (nextState sessionState) {
 	switch event {
 	case internal.PeerTimeout:
-		session.log.OnEvent("Session Timeout")
-		return latentState{}
+		//the first test request was sent on entering pendingTimeout
+		sent := len(session.pendingTestReqIDs)
+		if sent == 0 {
+			sent = 1
+		}
+
+		if sent >= session.MaxTestRequestsBeforeDisconnect {
+			session.log.OnEvent("Session Timeout")
+			return latentState{}
+		}
+
+		if err := session.sendTestRequest(); err != nil {
+			return handleStateError(session, err)
+		}
 	}
 
 	return s
@@ -1,8 +1,11 @@
 package quickfix
 
 import (
+	"bytes"
 	"testing"
+	"time"
 
+	"github.com/quickfixgo/quickfix/clocktest"
 	"github.com/quickfixgo/quickfix/internal"
 	"github.com/stretchr/testify/suite"
 )
@@ -50,6 +53,110 @@ func (s *PendingTimeoutTestSuite) TestSessionTimeout() {
 	}
 }
 
+func (s *PendingTimeoutTestSuite) TestMaxTestRequestsBeforeDisconnect() {
+	s.session.MaxTestRequestsBeforeDisconnect = 3
+	s.session.State = inSession{}
+
+	s.MockApp.On("ToAdmin").Return(nil)
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.State(pendingTimeout{inSession{}})
+
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.State(pendingTimeout{inSession{}})
+	s.MessageType(string(msgTypeTestRequest), s.MockApp.lastToAdmin)
+
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.State(pendingTimeout{inSession{}})
+	s.Len(s.session.pendingTestReqIDs, 3)
+	s.NextSenderMsgSeqNum(4)
+
+	s.MockApp.On("OnLogout").Return(nil)
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.MockApp.AssertExpectations(s.T())
+	s.State(latentState{})
+}
+
+func (s *PendingTimeoutTestSuite) TestFixMsgInHeartbeatReply() {
+	tests := []sessionState{inSession{}, resendState{resendRangeEnd: 10}}
+
+	for _, state := range tests {
+		s.SetupTest()
+		s.session.State = state
+
+		s.MockApp.On("ToAdmin").Return(nil)
+		s.session.Timeout(s.session, internal.PeerTimeout)
+		s.Require().Len(s.session.pendingTestReqIDs, 1)
+		s.State(pendingTimeout{state})
+
+		s.MockApp.On("FromAdmin").Return(nil)
+		//a heartbeat without TestReqID is not a reply
+		s.fixMsgIn(s.session, s.Heartbeat())
+		s.State(pendingTimeout{state})
+
+		heartbeat := s.Heartbeat()
+		heartbeat.Body.SetField(tagTestReqID, FIXString("UNKNOWN"))
+		s.fixMsgIn(s.session, heartbeat)
+		s.State(pendingTimeout{state})
+		s.IsType(state, s.session.State.(pendingTimeout).sessionState)
+
+		heartbeat = s.Heartbeat()
+		heartbeat.Body.SetField(tagTestReqID, FIXString(s.session.pendingTestReqIDs[0]))
+		s.fixMsgIn(s.session, heartbeat)
+		s.State(state)
+		s.Empty(s.session.pendingTestReqIDs)
+		s.NextTargetMsgSeqNum(4)
+	}
+}
+
+func (s *PendingTimeoutTestSuite) TestIncomingUnansweredHeartbeats() {
+	clock := clocktest.NewFakeClock(time.Now())
+	s.session.clock = clock
+	s.session.HeartBtInt = 30 * time.Second
+	s.session.MaxTestRequestsBeforeDisconnect = 2
+
+	var peerTimeouts int
+	s.session.peerTimer = internal.NewEventTimer(clock, func() { peerTimeouts++ })
+	defer s.session.peerTimer.Stop()
+
+	s.session.State = inSession{}
+	s.MockApp.On("ToAdmin").Return(nil)
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.State(pendingTimeout{inSession{}})
+
+	//heartbeats arriving every HeartBtInt without the TestReqID must not postpone the heartbeat timeout
+	s.MockApp.On("FromAdmin").Return(nil)
+	for i := 0; i < 2; i++ {
+		clock.Advance(s.session.HeartBtInt)
+
+		heartbeat := s.Heartbeat()
+		heartbeat.Header.SetField(tagSendingTime, FIXUTCTimestamp{Time: clock.Now()})
+		s.session.Incoming(s.session, fixIn{bytes: bytes.NewBuffer(heartbeat.build())})
+		s.State(pendingTimeout{inSession{}})
+	}
+
+	s.Equal(1, peerTimeouts)
+
+	//the next test request is sent on the peer timeout and the session disconnects once the limit is reached
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.Len(s.session.pendingTestReqIDs, 2)
+
+	s.MockApp.On("OnLogout").Return(nil)
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.State(latentState{})
+}
+
+func (s *PendingTimeoutTestSuite) TestFixMsgInEndsTimeout() {
+	s.session.State = inSession{}
+
+	s.MockApp.On("ToAdmin").Return(nil)
+	s.session.Timeout(s.session, internal.PeerTimeout)
+	s.State(pendingTimeout{inSession{}})
+
+	s.MockApp.On("FromApp").Return(nil)
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.State(inSession{})
+}
+
 func (s *PendingTimeoutTestSuite) TestTimeoutUnchangedState() {
 	tests := []pendingTimeout{
 		{inSession{}},
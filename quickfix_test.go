@@ -204,8 +204,12 @@ func (s *SessionSuiteRig) Init() {
 		log:          nullLog{},
 		messageOut:   s.Receiver.sendChannel,
 		sessionEvent: make(chan internal.Event),
+		clock:        internal.SystemClock{},
 	}
 	s.MaxLatency = 120 * time.Second
+	s.TestRequestDelayMultiplier = defaultTestRequestDelayMultiplier
+	s.HeartbeatTimeoutMultiplier = defaultHeartbeatTimeoutMultiplier
+	s.MaxTestRequestsBeforeDisconnect = 1
 }
 
 func (s *SessionSuiteRig) State(state sessionState) {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"strconv"
+
+	"github.com/quickfixgo/quickfix/datadictionary"
 )
 
 //GroupItem interface is used to construct repeating group templates
@@ -64,6 +66,12 @@ type RepeatingGroup struct {
 	tag      Tag
 	template GroupTemplate
 	groups   []*Group
+
+	//ordering caches the groupTagOrder of template
+	ordering tagOrder
+
+	//borrowed is set while groups are shared with the FieldMap of a parsed message, they must not be reused
+	borrowed bool
 }
 
 //NewRepeatingGroup returns an initilized RepeatingGroup instance
@@ -97,39 +105,75 @@ func (f RepeatingGroup) Get(i int) *Group {
 	return f.groups[i]
 }
 
-//Add appends a new group to the RepeatingGroup and returns the new Group
+//Add appends a new group to the RepeatingGroup and returns the new Group. Groups removed by Clear are reused.
 func (f *RepeatingGroup) Add() *Group {
+	n := len(f.groups)
+	if n < cap(f.groups) && f.groups[:n+1][n] != nil {
+		f.groups = f.groups[:n+1]
+		f.groups[n].Clear()
+		return f.groups[n]
+	}
+
 	g := new(Group)
-	g.initWithOrdering(f.groupTagOrder())
+	g.initWithOrdering(f.tagOrdering())
 
 	f.groups = append(f.groups, g)
 	return g
 }
 
+//Clear removes all groups. Their storage is reused by the Groups added next, so Groups returned before and messages
+//the RepeatingGroup was set on must no longer be used once a Group is added.
+func (f *RepeatingGroup) Clear() {
+	if f.borrowed {
+		f.groups, f.borrowed = nil, false
+		return
+	}
+
+	f.groups = f.groups[:0]
+}
+
+//repeatingGroupItem is implemented by RepeatingGroup and the types embedding it
+type repeatingGroupItem interface {
+	repeatingGroup() *RepeatingGroup
+}
+
+func (f *RepeatingGroup) repeatingGroup() *RepeatingGroup { return f }
+
 //Write returns tagValues for all Items in the repeating group ordered by
 //Group sequence and Group template order
 func (f RepeatingGroup) Write() []TagValue {
-	tvs := make([]TagValue, 1, 1)
-	tvs[0].init(f.tag, []byte(strconv.Itoa(len(f.groups))))
+	return f.writeTo(new(fieldStorage))
+}
 
+//writeTo returns the fields of the repeating group taking the TagValues from s, the NumInGroup field followed by the
+//fields of each Group in template order
+func (f *RepeatingGroup) writeTo(s *fieldStorage) field {
+	size := 1
 	for _, group := range f.groups {
-		tags := group.sortedTags()
+		for _, fields := range group.tagLookup {
+			size += len(fields)
+		}
+	}
 
-		for _, tag := range tags {
-			if fields, ok := group.tagLookup[tag]; ok {
-				tvs = append(tvs, fields...)
-			}
+	var count [20]byte
+	tvs := s.newField(size)
+	s.initTagValue(&tvs[0], f.tag, strconv.AppendInt(count[:0], int64(len(f.groups)), 10))
+
+	n := 1
+	for _, group := range f.groups {
+		for _, tag := range group.sortedTags() {
+			n += copy(tvs[n:], group.tagLookup[tag])
 		}
 	}
 
-	return tvs
+	return tvs[:n]
 }
 
 func (f RepeatingGroup) findItemInGroupTemplate(t Tag) (item GroupItem, ok bool) {
 	for _, templateField := range f.template {
 		if t == templateField.Tag() {
 			ok = true
-			item = templateField.Clone()
+			item = templateField
 			break
 		}
 	}
@@ -137,6 +181,14 @@ func (f RepeatingGroup) findItemInGroupTemplate(t Tag) (item GroupItem, ok bool)
 	return
 }
 
+func (f *RepeatingGroup) tagOrdering() tagOrder {
+	if f.ordering == nil {
+		f.ordering = f.groupTagOrder()
+	}
+
+	return f.ordering
+}
+
 func (f RepeatingGroup) groupTagOrder() tagOrder {
 	tagMap := make(map[Tag]int)
 	for i, f := range f.template {
@@ -173,33 +225,47 @@ func (f *RepeatingGroup) Read(tv []TagValue) ([]TagValue, error) {
 		return tv, err
 	}
 
+	f.Clear()
 	if expectedGroupSize == 0 {
 		return tv[1:], nil
 	}
 
 	tv = tv[1:cap(tv)]
-	tagOrdering := f.groupTagOrder()
-	group := new(Group)
-	group.initWithOrdering(tagOrdering)
+	var group *Group
 	for len(tv) > 0 {
-		gi, ok := f.findItemInGroupTemplate(tv[0].tag)
+		templateField, ok := f.findItemInGroupTemplate(tv[0].tag)
 		if !ok {
 			break
 		}
 
-		tvRange := tv
-		if tv, err = gi.Read(tv); err != nil {
-			return tv, err
+		if f.isDelimiter(templateField.Tag()) {
+			group = f.Add()
 		}
 
-		if f.isDelimiter(gi.Tag()) {
-			group = new(Group)
-			group.initWithOrdering(tagOrdering)
+		if group == nil {
+			return tv, repeatingGroupFieldsOutOfOrder(f.tag, fmt.Sprintf("group %v: field %v found before delimiter %v", f.tag, tv[0].tag, f.delimiter()))
+		}
+
+		tvRange := tv
+		switch item := templateField.(type) {
+		case protoGroupElement:
+			tv, err = item.Read(tv)
+		case repeatingGroupItem:
+			proto := item.repeatingGroup()
+			nested := group.reusableRepeatingGroup(proto.tag, proto.template)
+			if tv, err = nested.Read(tv); err == nil {
+				group.addRepeatingGroup(tvRange[:len(tvRange)-len(tv)], nested)
+				continue
+			}
+		default:
+			tv, err = item.Clone().Read(tv)
+		}
 
-			f.groups = append(f.groups, group)
+		if err != nil {
+			return tv, err
 		}
 
-		group.tagLookup[tvRange[0].tag] = tvRange
+		group.add(tvRange[:len(tvRange)-len(tv)])
 	}
 
 	if len(f.groups) != expectedGroupSize {
@@ -208,3 +274,72 @@ func (f *RepeatingGroup) Read(tv []TagValue) ([]TagValue, error) {
 
 	return tv, err
 }
+
+//groupTemplate returns the GroupTemplate for the repeating group defined by fieldDef, including nested groups. The
+//template is built once and kept with the FieldDef.
+func groupTemplate(fieldDef *datadictionary.FieldDef) GroupTemplate {
+	return fieldDef.Derived(func() interface{} {
+		template := make(GroupTemplate, len(fieldDef.Fields))
+		for i, child := range fieldDef.Fields {
+			if child.IsGroup() {
+				template[i] = NewRepeatingGroup(Tag(child.Tag()), groupTemplate(child))
+			} else {
+				template[i] = GroupElement(Tag(child.Tag()))
+			}
+		}
+
+		return template
+	}).(GroupTemplate)
+}
+
+//parseRepeatingGroups rebuilds the FieldMaps of a parsed message with the repeating groups defined by the
+//DataDictionaries, so fields repeated within groups are not collapsed. Groups that cannot be read are left flat for
+//validation to reject.
+func (m *Message) parseRepeatingGroups(dicts dataDictionaries) {
+	if dicts[0] == nil && dicts[1] == nil {
+		return
+	}
+
+	msgType, err := m.Header.GetBytes(tagMsgType)
+	if err != nil {
+		return
+	}
+
+	hasGroups := false
+	for _, f := range m.fields {
+		if fieldDef, ok := dicts.fieldDef(f.tag, msgType); ok && fieldDef.IsGroup() {
+			hasGroups = true
+			break
+		}
+	}
+
+	if !hasGroups {
+		return
+	}
+
+	m.Header.Clear()
+	m.Body.Clear()
+	m.Trailer.Clear()
+
+	for fields := m.fields[:len(m.fields):len(m.fields)]; len(fields) > 0; {
+		fieldMap := &m.Body.FieldMap
+		switch {
+		case isHeaderField(fields[0].tag, dicts[0]):
+			fieldMap = &m.Header.FieldMap
+		case isTrailerField(fields[0].tag, dicts[0]):
+			fieldMap = &m.Trailer.FieldMap
+		}
+
+		if fieldDef, ok := dicts.fieldDef(fields[0].tag, msgType); ok && fieldDef.IsGroup() {
+			rg := fieldMap.reusableRepeatingGroup(fields[0].tag, groupTemplate(fieldDef))
+			if remaining, err := rg.Read(fields); err == nil {
+				fieldMap.addRepeatingGroup(fields[:len(fields)-len(remaining)], rg)
+				fields = remaining
+				continue
+			}
+		}
+
+		fieldMap.add(fields[:1])
+		fields = fields[1:]
+	}
+}
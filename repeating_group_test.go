@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"testing"
 
+	"github.com/quickfixgo/quickfix/datadictionary"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -274,3 +275,165 @@ func TestRepeatingGroup_ReadComplete(t *testing.T) {
 		}
 	}
 }
+
+func partiesTemplate(partyIDFields ...GroupItem) GroupTemplate {
+	return append(partyIDFields, NewRepeatingGroup(Tag(802), GroupTemplate{GroupElement(523), GroupElement(803)}))
+}
+
+func buildPartiesMessage(template GroupTemplate) *bytes.Buffer {
+	msg := NewMessage()
+	msg.Header.SetField(tagBeginString, FIXString(BeginStringFIX44))
+	msg.Header.SetField(tagMsgType, FIXString("D"))
+	msg.Header.SetField(tagMsgSeqNum, FIXInt(2))
+	msg.Header.SetField(tagSenderCompID, FIXString("TW"))
+	msg.Header.SetField(tagTargetCompID, FIXString("ISLD"))
+	msg.Header.SetField(tagSendingTime, FIXString("20140515-19:49:56.659"))
+	msg.Body.SetField(Tag(11), FIXString("100"))
+	msg.Body.SetField(Tag(55), FIXString("TSLA"))
+
+	parties := NewRepeatingGroup(Tag(453), template)
+	party := parties.Add()
+	party.SetField(Tag(448), FIXString("PARTY1")).SetField(Tag(447), FIXString("D")).SetField(Tag(452), FIXInt(1))
+	subIDs := NewRepeatingGroup(Tag(802), GroupTemplate{GroupElement(523), GroupElement(803)})
+	subIDs.Add().SetField(Tag(523), FIXString("SUB1")).SetField(Tag(803), FIXInt(1))
+	party.SetGroup(subIDs)
+	parties.Add().SetField(Tag(448), FIXString("PARTY2")).SetField(Tag(447), FIXString("D")).SetField(Tag(452), FIXInt(3))
+	msg.Body.SetGroup(parties)
+
+	return bytes.NewBuffer(msg.build())
+}
+
+func TestRepeatingGroup_ParseWithDataDictionary(t *testing.T) {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	require.Nil(t, err)
+
+	rawMsg := buildPartiesMessage(partiesTemplate(GroupElement(448), GroupElement(447), GroupElement(452)))
+	expectedBytes := string(rawMsg.Bytes())
+
+	msg := NewMessage()
+	require.Nil(t, ParseMessageWithDataDictionary(msg, rawMsg, dict, dict))
+
+	assert.False(t, msg.Body.Has(Tag(448)), "group fields should not be collapsed into the body")
+	assert.True(t, msg.Body.Has(Tag(55)))
+
+	copied := NewMessage()
+	msg.CopyInto(copied)
+
+	for _, m := range []*Message{msg, copied} {
+		parties := NewRepeatingGroup(Tag(453), nil)
+		require.Nil(t, m.Body.GetGroup(parties))
+		require.Equal(t, 2, parties.Len())
+
+		for i, expected := range []string{"PARTY1", "PARTY2"} {
+			partyID, err := parties.Get(i).GetString(Tag(448))
+			require.Nil(t, err)
+			assert.Equal(t, expected, partyID)
+		}
+
+		subIDs := NewRepeatingGroup(Tag(802), nil)
+		require.Nil(t, parties.Get(0).GetGroup(subIDs))
+		require.Equal(t, 1, subIDs.Len())
+		subID, err := subIDs.Get(0).GetString(Tag(523))
+		require.Nil(t, err)
+		assert.Equal(t, "SUB1", subID)
+
+		assert.False(t, parties.Get(1).Has(Tag(802)))
+	}
+
+	assert.Equal(t, expectedBytes, string(msg.build()))
+}
+
+func TestRepeatingGroup_ValidateParsedWithDataDictionary(t *testing.T) {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	require.Nil(t, err)
+
+	var tests = []struct {
+		template       GroupTemplate
+		expectedReason int
+	}{
+		{partiesTemplate(GroupElement(448), GroupElement(447), GroupElement(452)), 0},
+		{partiesTemplate(GroupElement(448), GroupElement(452), GroupElement(447)), rejectReasonTagSpecifiedOutOfRequiredOrder},
+	}
+
+	for _, test := range tests {
+		msg := NewMessage()
+		require.Nil(t, ParseMessageWithDataDictionary(msg, buildPartiesMessage(test.template), dict, dict))
+		require.True(t, msg.Body.Has(Tag(453)))
+		require.NotNil(t, msg.Body.groups[Tag(453)], "expected group structure from parse")
+
+		rej := validateWalk(dict, dict, "D", msg)
+		if test.expectedReason == 0 {
+			assert.Nil(t, rej)
+			continue
+		}
+
+		require.NotNil(t, rej)
+		assert.Equal(t, test.expectedReason, rej.RejectReason())
+		assert.Equal(t, Tag(447), *rej.RefTagID())
+	}
+}
+
+func TestRepeatingGroup_ReparseReusesGroups(t *testing.T) {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	require.Nil(t, err)
+
+	twoParties := buildPartiesMessage(partiesTemplate(GroupElement(448), GroupElement(447), GroupElement(452)))
+	oneParty := NewMessage()
+	setBenchmarkHeader(oneParty, "D")
+	parties := NewRepeatingGroup(Tag(453), GroupTemplate{GroupElement(448), GroupElement(447), GroupElement(452)})
+	parties.Add().SetString(Tag(448), "PARTY3").SetString(Tag(447), "D").SetInt(Tag(452), 4)
+	oneParty.Body.SetGroup(parties)
+	onePartyBytes := bytes.NewBuffer(oneParty.build())
+
+	msg := NewMessage()
+	for _, test := range []struct {
+		rawMsg           *bytes.Buffer
+		expectedPartyIDs []string
+	}{
+		{twoParties, []string{"PARTY1", "PARTY2"}},
+		{onePartyBytes, []string{"PARTY3"}},
+		{twoParties, []string{"PARTY1", "PARTY2"}},
+	} {
+		require.Nil(t, ParseMessageWithDataDictionary(msg, test.rawMsg, dict, dict))
+
+		parsed := NewRepeatingGroup(Tag(453), nil)
+		require.Nil(t, msg.Body.GetGroup(parsed))
+		require.Equal(t, len(test.expectedPartyIDs), parsed.Len())
+		for i, expected := range test.expectedPartyIDs {
+			partyID, err := parsed.Get(i).GetString(Tag(448))
+			require.Nil(t, err)
+			assert.Equal(t, expected, partyID)
+			assert.Equal(t, i == 0 && expected == "PARTY1", parsed.Get(i).Has(Tag(802)))
+		}
+	}
+}
+
+func TestRepeatingGroup_ClearReusesGroups(t *testing.T) {
+	parties := NewRepeatingGroup(Tag(453), GroupTemplate{GroupElement(448), GroupElement(447), GroupElement(452)})
+	first := parties.Add().SetString(Tag(448), "PARTY1")
+	parties.Add().SetString(Tag(448), "PARTY2")
+
+	parties.Clear()
+	assert.Equal(t, 0, parties.Len())
+
+	reused := parties.Add()
+	assert.True(t, first == &reused.FieldMap, "expected the first group to be reused")
+	assert.False(t, reused.Has(Tag(448)))
+
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	require.Nil(t, err)
+
+	msg := NewMessage()
+	require.Nil(t, ParseMessageWithDataDictionary(msg, buildPartiesMessage(partiesTemplate(GroupElement(448), GroupElement(447), GroupElement(452))), dict, dict))
+
+	//groups taken from a parsed message are not reused
+	parsed := NewRepeatingGroup(Tag(453), nil)
+	require.Nil(t, msg.Body.GetGroup(parsed))
+	parsed.Clear()
+	parsed.Add().SetString(Tag(448), "PARTY3")
+
+	require.Nil(t, msg.Body.GetGroup(parsed))
+	partyID, err := parsed.Get(0).GetString(Tag(448))
+	require.Nil(t, err)
+	assert.Equal(t, "PARTY1", partyID)
+}
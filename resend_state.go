@@ -1,17 +1,26 @@
 package quickfix
 
-import "github.com/quickfixgo/quickfix/internal"
+import (
+	"bytes"
+
+	"github.com/quickfixgo/quickfix/internal"
+)
 
 type resendState struct {
 	loggedOn
 	messageStash          map[int]*Message
 	currentResendRangeEnd int
 	resendRangeEnd        int
+	resendRequestRetries  int
 }
 
 func (s resendState) String() string { return "Resend" }
 
 func (s resendState) Timeout(session *session, event internal.Event) (nextState sessionState) {
+	if event == internal.ResendRequestTimeout {
+		return s.handleResendRequestTimeout(session)
+	}
+
 	nextState = inSession{}.Timeout(session, event)
 	switch nextState.(type) {
 	case inSession:
@@ -26,12 +35,19 @@ func (s resendState) Timeout(session *session, event internal.Event) (nextState
 }
 
 func (s resendState) FixMsgIn(session *session, msg *Message) (nextState sessionState) {
+	expectedSeqNum := session.store.NextTargetMsgSeqNum()
 	nextState = inSession{}.FixMsgIn(session, msg)
 
 	if !nextState.IsLoggedOn() {
 		return
 	}
 
+	if session.store.NextTargetMsgSeqNum() > expectedSeqNum {
+		//the counterparty is answering, restart the wait
+		s.resendRequestRetries = 0
+		session.resetResendTimer()
+	}
+
 	if s.currentResendRangeEnd != 0 && s.currentResendRangeEnd < session.store.NextTargetMsgSeqNum() {
 		nextResendState, err := session.sendResendRequest(session.store.NextTargetMsgSeqNum(), s.resendRangeEnd)
 		if err != nil {
@@ -54,10 +70,20 @@ func (s resendState) FixMsgIn(session *session, msg *Message) (nextState session
 
 		delete(s.messageStash, targetSeqNum)
 
-		//return stashed message to pool
-		session.returnToPool(msg)
+		if msgType, _ := msg.Header.GetBytes(tagMsgType); bytes.Equal(msgType, msgTypeLogon) {
+			//a stashed logon has already been processed
+			msg.Release()
+			if err := session.store.IncrNextTargetMsgSeqNum(); err != nil {
+				return handleStateError(session, err)
+			}
+			continue
+		}
 
 		nextState = inSession{}.FixMsgIn(session, msg)
+
+		//return stashed message to pool
+		msg.Release()
+
 		if !nextState.IsLoggedOn() {
 			return
 		}
@@ -65,3 +91,33 @@ func (s resendState) FixMsgIn(session *session, msg *Message) (nextState session
 
 	return
 }
+
+//handleResendRequestTimeout sends the ResendRequest again for the messages still missing, or logs out once
+//MaxResendRequestRetries is reached
+func (s resendState) handleResendRequestTimeout(session *session) (nextState sessionState) {
+	expectedSeqNum := session.store.NextTargetMsgSeqNum()
+	if s.resendRequestRetries >= session.MaxResendRequestRetries {
+		session.log.OnEventf("ResendRequest timed out expecting %v, logging out after %v retries", expectedSeqNum, s.resendRequestRetries)
+		if err := session.initiateLogout("ResendRequest timed out"); err != nil {
+			return handleStateError(session, err)
+		}
+		return logoutState{}
+	}
+
+	session.log.OnEventf("ResendRequest timed out expecting %v, retry %v of %v", expectedSeqNum, s.resendRequestRetries+1, session.MaxResendRequestRetries)
+	nextResendState, err := session.sendResendRequest(expectedSeqNum, s.resendRangeEnd)
+	if err != nil {
+		return handleStateError(session, err)
+	}
+
+	nextResendState.messageStash = s.messageStash
+	nextResendState.resendRequestRetries = s.resendRequestRetries + 1
+	return nextResendState
+}
+
+//resetResendTimer schedules the ResendRequestTimeout of a resend in progress, if enabled
+func (s *session) resetResendTimer() {
+	if s.ResendRequestTimeout > 0 {
+		s.resendTimer.Reset(s.ResendRequestTimeout)
+	}
+}
@@ -2,7 +2,9 @@ package quickfix
 
 import (
 	"testing"
+	"time"
 
+	"github.com/quickfixgo/quickfix/clocktest"
 	"github.com/quickfixgo/quickfix/internal"
 	"github.com/stretchr/testify/suite"
 )
@@ -173,3 +175,99 @@ func (s *resendStateTestSuite) TestFixMsgInResendChunk() {
 	s.FieldEquals(tagBeginSeqNo, 3, s.MockApp.lastToAdmin.Body)
 	s.FieldEquals(tagEndSeqNo, 0, s.MockApp.lastToAdmin.Body)
 }
+
+func (s *resendStateTestSuite) TestTimeoutResendRequestTimeout() {
+	s.session.State = inSession{}
+	s.MaxResendRequestRetries = 2
+
+	s.MessageFactory.SetNextSeqNum(3)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.State(resendState{})
+	s.LastToAdminMessageSent()
+
+	for retry := 1; retry <= 2; retry++ {
+		s.session.Timeout(s.session, internal.ResendRequestTimeout)
+
+		s.LastToAdminMessageSent()
+		s.MessageType(string(msgTypeResendRequest), s.MockApp.lastToAdmin)
+		s.FieldEquals(tagBeginSeqNo, 1, s.MockApp.lastToAdmin.Body)
+		s.State(resendState{})
+		s.Equal(retry, s.session.State.(resendState).resendRequestRetries)
+		s.Len(s.session.State.(resendState).messageStash, 1)
+	}
+
+	s.session.Timeout(s.session, internal.ResendRequestTimeout)
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagText, "ResendRequest timed out", s.MockApp.lastToAdmin.Body)
+	s.State(logoutState{})
+}
+
+func (s *resendStateTestSuite) TestFixMsgInProgressResetsResendRequestRetries() {
+	s.session.State = inSession{}
+	s.MaxResendRequestRetries = 2
+
+	s.MessageFactory.SetNextSeqNum(3)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.LastToAdminMessageSent()
+
+	s.session.Timeout(s.session, internal.ResendRequestTimeout)
+	s.LastToAdminMessageSent()
+	s.Equal(1, s.session.State.(resendState).resendRequestRetries)
+
+	s.MessageFactory.SetNextSeqNum(1)
+	s.MockApp.On("FromApp").Return(nil)
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.NextTargetMsgSeqNum(2)
+	s.State(resendState{})
+	s.Equal(0, s.session.State.(resendState).resendRequestRetries)
+}
+
+func (s *resendStateTestSuite) TestFixMsgInMaxMessageStashSize() {
+	s.session.State = inSession{}
+	s.MaxMessageStashSize = 2
+
+	s.MessageFactory.SetNextSeqNum(2)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.LastToAdminMessageSent()
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.State(resendState{})
+	s.NoMessageSent()
+
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+	s.State(logoutState{})
+}
+
+func (s *resendStateTestSuite) TestResendRequestTimeoutTimer() {
+	clock := clocktest.NewFakeClock(time.Now())
+	fired := 0
+	s.session.resendTimer = internal.NewEventTimer(clock, func() { fired++ })
+	s.session.State = inSession{}
+
+	s.MessageFactory.SetNextSeqNum(3)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.LastToAdminMessageSent()
+	s.Equal(0, clock.PendingTimers(), "no timeout unless ResendRequestTimeout is set")
+
+	s.SetupTest()
+	s.session.resendTimer = internal.NewEventTimer(clock, func() { fired++ })
+	s.session.State = inSession{}
+	s.ResendRequestTimeout = 5 * time.Second
+
+	s.MessageFactory.SetNextSeqNum(3)
+	s.MockApp.On("ToAdmin")
+	s.fixMsgIn(s.session, s.NewOrderSingle())
+	s.LastToAdminMessageSent()
+	s.Equal(1, clock.PendingTimers())
+
+	clock.Advance(4 * time.Second)
+	s.Equal(0, fired)
+	clock.Advance(time.Second)
+	s.Equal(1, fired)
+}
@@ -0,0 +1,802 @@
+package sbe
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/shopspring/decimal"
+)
+
+//Codec encodes quickfix messages as the SBE messages of a Schema, and decodes SBE messages to quickfix messages.
+//
+//SBE messages are matched to FIX messages by their semantic type, the FIX MsgType. Fields and data are matched by id,
+//the FIX tag, and repeating groups by id, the tag of the FIX NumInGroup field. Only the message body is encoded, the
+//FIX session header and trailer are not part of SBE messages. Messages are encoded in the version of the Schema, and
+//decoded in the version of their message header, without the fields, groups and data added since that version.
+//
+//Set fields are FIX multiple value fields. Each choice of a set stands for the enumerated value of the field in the
+//DataDictionary whose description matches the choice name, ignoring case and underscores, so the choice DoNotIncrease
+//of ExecInst is the value E described as DO_NOT_INCREASE.
+type Codec struct {
+	Schema *Schema
+
+	//BeginString is set in the header of decoded messages, if not empty
+	BeginString string
+
+	//DataDictionary defines the enumerated values of set fields. Required to encode or decode messages with sets.
+	DataDictionary *datadictionary.DataDictionary
+}
+
+//NewCodec returns a Codec for the messages of schema
+func NewCodec(schema *Schema) *Codec {
+	return &Codec{Schema: schema}
+}
+
+//Encode returns the SBE message, with message header, encoding msg
+func (c Codec) Encode(msg *quickfix.Message) ([]byte, error) {
+	msgType, err := msg.MsgType()
+	if err != nil {
+		return nil, err
+	}
+
+	messageDef, ok := c.Schema.MessageByMsgType(msgType)
+	if !ok {
+		return nil, fmt.Errorf("message type %v is not defined in schema %v", msgType, c.Schema.ID)
+	}
+
+	for _, tag := range msg.Body.Tags() {
+		if !messageDef.hasTag(int(tag)) {
+			return nil, fmt.Errorf("tag %v is not defined for message %v", tag, messageDef.Name)
+		}
+	}
+
+	b := make([]byte, c.Schema.HeaderType.Size(), c.Schema.HeaderType.Size()+messageDef.BlockLength)
+	header := map[string]int{
+		"blockLength": messageDef.BlockLength,
+		"templateId":  messageDef.ID,
+		"schemaId":    c.Schema.ID,
+		"version":     c.Schema.Version,
+	}
+	for name, value := range header {
+		if err := c.writeMember(c.Schema.HeaderType, name, b, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.encodeBlock(b, &messageDef.Block, &msg.Body.FieldMap)
+}
+
+//Decode decodes the SBE message, with message header, at the start of data into msg, returning the number of bytes
+//read. The header and body of msg are cleared before decoding, msg is typically a new Message.
+func (c Codec) Decode(msg *quickfix.Message, data []byte) (int, error) {
+	headerSize := c.Schema.HeaderType.Size()
+	if len(data) < headerSize {
+		return 0, errShortMessage
+	}
+
+	blockLength, err := c.readMember(c.Schema.HeaderType, "blockLength", data)
+	if err != nil {
+		return 0, err
+	}
+
+	templateID, err := c.readMember(c.Schema.HeaderType, "templateId", data)
+	if err != nil {
+		return 0, err
+	}
+
+	if schemaID, err := c.readMember(c.Schema.HeaderType, "schemaId", data); err == nil && schemaID != c.Schema.ID {
+		return 0, fmt.Errorf("message of schema %v, expected schema %v", schemaID, c.Schema.ID)
+	}
+
+	messageDef, ok := c.Schema.MessageByID(templateID)
+	if !ok {
+		return 0, fmt.Errorf("unknown template id %v", templateID)
+	}
+
+	//members added after the acting version of the message are not encoded
+	version, err := c.readMember(c.Schema.HeaderType, "version", data)
+	if err != nil {
+		version = c.Schema.Version
+	}
+
+	msg.Header.Clear()
+	msg.Body.Clear()
+	msg.Trailer.Clear()
+
+	if c.BeginString != "" {
+		msg.Header.SetString(tagBeginString, c.BeginString)
+	}
+	msg.Header.SetString(tagMsgType, messageDef.SemanticType)
+
+	return c.decodeBlock(data, headerSize, &messageDef.Block, blockLength, version, &msg.Body.FieldMap)
+}
+
+const (
+	tagBeginString quickfix.Tag = 8
+	tagMsgType     quickfix.Tag = 35
+)
+
+var errShortMessage = errors.New("message is shorter than its encoding")
+
+func (c Codec) encodeBlock(b []byte, block *Block, m *quickfix.FieldMap) ([]byte, error) {
+	start := len(b)
+	b = append(b, make([]byte, block.BlockLength)...)
+
+	for _, field := range block.Fields {
+		if field.Presence == Constant {
+			continue
+		}
+
+		if field.Offset+field.Size() > block.BlockLength {
+			return nil, fmt.Errorf("field %v exceeds blockLength %v", field.Name, block.BlockLength)
+		}
+
+		fieldBytes := b[start+field.Offset : start+field.Offset+field.Size()]
+		tag := quickfix.Tag(field.ID)
+		if !m.Has(tag) {
+			if err := c.encodeNull(field.Type, fieldBytes); err != nil {
+				return nil, fmt.Errorf("field %v: %v", field.Name, err)
+			}
+			continue
+		}
+
+		value, rej := m.GetString(tag)
+		if rej != nil {
+			return nil, rej
+		}
+
+		var err error
+		if field.Type.Kind == Set {
+			err = c.encodeSet(field, fieldBytes, value)
+		} else {
+			err = c.encodeValue(field.Type, field.SemanticType, fieldBytes, value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("field %v: %v", field.Name, err)
+		}
+	}
+
+	for _, group := range block.Groups {
+		rg := quickfix.NewRepeatingGroup(quickfix.Tag(group.ID), groupTemplate(group))
+		if m.Has(rg.Tag()) {
+			if err := m.GetGroup(rg); err != nil {
+				return nil, err
+			}
+		}
+
+		dimension := make([]byte, group.DimensionType.Size())
+		if err := c.writeMember(group.DimensionType, "blockLength", dimension, group.BlockLength); err != nil {
+			return nil, err
+		}
+		if err := c.writeMember(group.DimensionType, "numInGroup", dimension, rg.Len()); err != nil {
+			return nil, err
+		}
+		b = append(b, dimension...)
+
+		var err error
+		for i := 0; i < rg.Len(); i++ {
+			if b, err = c.encodeBlock(b, &group.Block, &rg.Get(i).FieldMap); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, data := range block.Data {
+		lengthType, ok := data.Type.Member("length")
+		if !ok {
+			return nil, fmt.Errorf("data %v: type %v has no length", data.Name, data.Type.Name)
+		}
+
+		var value []byte
+		if m.Has(quickfix.Tag(data.ID)) {
+			value, _ = m.GetBytes(quickfix.Tag(data.ID))
+		}
+
+		length := make([]byte, lengthType.Size())
+		writeRaw(lengthType.PrimitiveType, length, uint64(len(value)), c.Schema.ByteOrder)
+		b = append(b, length...)
+		b = append(b, value...)
+	}
+
+	return b, nil
+}
+
+func (c Codec) decodeBlock(data []byte, pos int, block *Block, blockLength, version int, m *quickfix.FieldMap) (int, error) {
+	if len(data) < pos+blockLength {
+		return 0, errShortMessage
+	}
+
+	blockBytes := data[pos : pos+blockLength]
+	for _, field := range block.Fields {
+		if field.SinceVersion > version {
+			continue
+		}
+
+		tag := quickfix.Tag(field.ID)
+		if field.Presence == Constant {
+			m.SetString(tag, field.ConstValue)
+			continue
+		}
+
+		//fields beyond the block of an older message are absent
+		if field.Offset+field.Size() > blockLength {
+			continue
+		}
+
+		var value string
+		var ok bool
+		var err error
+		if field.Type.Kind == Set {
+			value, ok, err = c.decodeSet(field, blockBytes[field.Offset:field.Offset+field.Size()])
+		} else {
+			value, ok, err = c.decodeValue(field.Type, field.SemanticType, blockBytes[field.Offset:field.Offset+field.Size()])
+		}
+		if err != nil {
+			return 0, fmt.Errorf("field %v: %v", field.Name, err)
+		}
+
+		if ok {
+			m.SetString(tag, value)
+		}
+	}
+	pos += blockLength
+
+	for _, group := range block.Groups {
+		if group.SinceVersion > version {
+			continue
+		}
+
+		if len(data) < pos+group.DimensionType.Size() {
+			return 0, errShortMessage
+		}
+
+		groupBlockLength, err := c.readMember(group.DimensionType, "blockLength", data[pos:])
+		if err != nil {
+			return 0, err
+		}
+
+		numInGroup, err := c.readMember(group.DimensionType, "numInGroup", data[pos:])
+		if err != nil {
+			return 0, err
+		}
+		pos += group.DimensionType.Size()
+
+		rg := quickfix.NewRepeatingGroup(quickfix.Tag(group.ID), groupTemplate(group))
+		for i := 0; i < numInGroup; i++ {
+			if pos, err = c.decodeBlock(data, pos, &group.Block, groupBlockLength, version, &rg.Add().FieldMap); err != nil {
+				return 0, err
+			}
+		}
+
+		if numInGroup > 0 {
+			m.SetGroup(rg)
+		}
+	}
+
+	for _, dataDef := range block.Data {
+		if dataDef.SinceVersion > version {
+			continue
+		}
+
+		lengthType, ok := dataDef.Type.Member("length")
+		if !ok {
+			return 0, fmt.Errorf("data %v: type %v has no length", dataDef.Name, dataDef.Type.Name)
+		}
+
+		if len(data) < pos+lengthType.Size() {
+			return 0, errShortMessage
+		}
+
+		length := int(readRaw(lengthType.PrimitiveType, data[pos:], c.Schema.ByteOrder))
+		pos += lengthType.Size()
+		if len(data) < pos+length {
+			return 0, errShortMessage
+		}
+
+		if length > 0 {
+			m.SetBytes(quickfix.Tag(dataDef.ID), append([]byte(nil), data[pos:pos+length]...))
+		}
+		pos += length
+	}
+
+	return pos, nil
+}
+
+//groupTemplate returns the template of the FIX repeating group of group, its fields followed by its groups and data
+func groupTemplate(group *GroupDef) quickfix.GroupTemplate {
+	var template quickfix.GroupTemplate
+	for _, field := range group.Fields {
+		template = append(template, quickfix.GroupElement(quickfix.Tag(field.ID)))
+	}
+
+	for _, nested := range group.Groups {
+		template = append(template, quickfix.NewRepeatingGroup(quickfix.Tag(nested.ID), groupTemplate(nested)))
+	}
+
+	for _, data := range group.Data {
+		template = append(template, quickfix.GroupElement(quickfix.Tag(data.ID)))
+	}
+
+	return template
+}
+
+func (b *Block) hasTag(tag int) bool {
+	for _, field := range b.Fields {
+		if field.ID == tag {
+			return true
+		}
+	}
+
+	for _, group := range b.Groups {
+		if group.ID == tag {
+			return true
+		}
+	}
+
+	for _, data := range b.Data {
+		if data.ID == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+//memberBytes returns the bytes of the composite member named name within the bytes of composite t
+func memberBytes(t *Type, name string, b []byte) (*Type, []byte, bool) {
+	var offset int
+	for _, member := range t.Members {
+		if member.Offset >= 0 {
+			offset = member.Offset
+		}
+
+		if member.Name == name {
+			return member, b[offset : offset+member.Size()], true
+		}
+		offset += member.Size()
+	}
+
+	return nil, nil, false
+}
+
+func (c Codec) readMember(t *Type, name string, b []byte) (int, error) {
+	member, memberBytes, ok := memberBytes(t, name, b)
+	if !ok {
+		return 0, fmt.Errorf("%v has no %v", t.Name, name)
+	}
+
+	if member.Presence == Constant {
+		var value int
+		_, err := fmt.Sscan(member.ConstValue, &value)
+		return value, err
+	}
+
+	return int(readRaw(member.PrimitiveType, memberBytes, c.Schema.ByteOrder)), nil
+}
+
+func (c Codec) writeMember(t *Type, name string, b []byte, value int) error {
+	member, memberBytes, ok := memberBytes(t, name, b)
+	if !ok {
+		return fmt.Errorf("%v has no %v", t.Name, name)
+	}
+
+	if member.Presence != Constant {
+		writeRaw(member.PrimitiveType, memberBytes, uint64(value), c.Schema.ByteOrder)
+	}
+
+	return nil
+}
+
+//decodeValue returns the FIX value of the encoding b of type t, false if the value is null
+func (c Codec) decodeValue(t *Type, semanticType string, b []byte) (string, bool, error) {
+	if t.Presence == Constant {
+		return t.ConstValue, true, nil
+	}
+
+	switch t.Kind {
+	case Simple:
+		if t.PrimitiveType == "char" && t.Length > 1 {
+			if i := bytes.IndexByte(b, 0); i >= 0 {
+				b = b[:i]
+			}
+			return string(b), len(b) > 0, nil
+		}
+
+		if t.Length != 1 {
+			return "", false, fmt.Errorf("arrays of %v are not supported", t.PrimitiveType)
+		}
+
+		raw := readRaw(t.PrimitiveType, b, c.Schema.ByteOrder)
+		if (t.Presence == Optional || t.PrimitiveType == "char") && isNull(t, raw) {
+			return "", false, nil
+		}
+
+		if isDateSemanticType(semanticType) {
+			return time.Unix(int64(raw)*24*60*60, 0).UTC().Format("20060102"), true, nil
+		}
+
+		return formatRaw(t.PrimitiveType, raw), true, nil
+
+	case Enum:
+		raw := readRaw(t.PrimitiveType, b, c.Schema.ByteOrder)
+		if isNull(t, raw) {
+			return "", false, nil
+		}
+		return formatRaw(t.PrimitiveType, raw), true, nil
+	}
+
+	switch {
+	case isDecimal(t):
+		mantissaType, mantissaBytes, _ := memberBytes(t, "mantissa", b)
+		mantissa, ok, err := c.decodeValue(mantissaType, "", mantissaBytes)
+		if !ok || err != nil {
+			return "", false, err
+		}
+
+		exponentType, exponentBytes, _ := memberBytes(t, "exponent", b)
+		exponent, _, err := c.decodeValue(exponentType, "", exponentBytes)
+		if err != nil {
+			return "", false, err
+		}
+
+		return decimalString(mantissa, exponent)
+
+	case isTimestamp(t):
+		timeType, timeBytes, _ := memberBytes(t, "time", b)
+		raw := readRaw(timeType.PrimitiveType, timeBytes, c.Schema.ByteOrder)
+		if timeType.Presence == Optional && isNull(timeType, raw) {
+			return "", false, nil
+		}
+
+		unit, err := c.readMember(t, "unit", b)
+		if err != nil {
+			return "", false, err
+		}
+
+		scale, precision, err := timeUnit(unit)
+		if err != nil {
+			return "", false, err
+		}
+
+		timestamp := quickfix.FIXUTCTimestamp{Time: time.Unix(0, int64(raw)*scale).UTC(), Precision: precision}
+		return string(timestamp.Write()), true, nil
+
+	case isMonthYear(t):
+		yearType, yearBytes, _ := memberBytes(t, "year", b)
+		year := readRaw(yearType.PrimitiveType, yearBytes, c.Schema.ByteOrder)
+		if isNull(yearType, year) {
+			return "", false, nil
+		}
+
+		monthYear := quickfix.FIXMonthYear{Year: int(year)}
+		for name, value := range map[string]*int{"day": &monthYear.Day, "week": &monthYear.Week} {
+			if memberType, memberBytes, ok := memberBytes(t, name, b); ok {
+				if raw := readRaw(memberType.PrimitiveType, memberBytes, c.Schema.ByteOrder); !isNull(memberType, raw) {
+					*value = int(raw)
+				}
+			}
+		}
+
+		month, err := c.readMember(t, "month", b)
+		if err != nil {
+			return "", false, err
+		}
+		monthYear.Month = time.Month(month)
+
+		return string(monthYear.Write()), true, nil
+	}
+
+	return "", false, fmt.Errorf("composite %v is not supported", t.Name)
+}
+
+//encodeValue encodes the FIX value as type t into b
+func (c Codec) encodeValue(t *Type, semanticType string, b []byte, value string) error {
+	if t.Presence == Constant {
+		return nil
+	}
+
+	switch t.Kind {
+	case Simple:
+		if t.PrimitiveType == "char" && t.Length > 1 {
+			if len(value) > len(b) {
+				return fmt.Errorf("value %v exceeds length %v", value, t.Length)
+			}
+			copy(b, value)
+			return nil
+		}
+
+		if t.Length != 1 {
+			return fmt.Errorf("arrays of %v are not supported", t.PrimitiveType)
+		}
+
+		if isDateSemanticType(semanticType) {
+			date, err := time.Parse("20060102", value)
+			if err != nil {
+				return err
+			}
+			writeRaw(t.PrimitiveType, b, uint64(date.Unix()/(24*60*60)), c.Schema.ByteOrder)
+			return nil
+		}
+
+		raw, err := parseRaw(t.PrimitiveType, value)
+		if err != nil {
+			return err
+		}
+		writeRaw(t.PrimitiveType, b, raw, c.Schema.ByteOrder)
+		return nil
+
+	case Enum:
+		for _, validValue := range t.ValidValues {
+			if validValue.Value == value {
+				raw, err := parseRaw(t.PrimitiveType, value)
+				if err != nil {
+					return err
+				}
+				writeRaw(t.PrimitiveType, b, raw, c.Schema.ByteOrder)
+				return nil
+			}
+		}
+		return fmt.Errorf("%v is not a valid value of %v", value, t.Name)
+	}
+
+	switch {
+	case isDecimal(t):
+		d, err := decimal.NewFromString(value)
+		if err != nil {
+			return err
+		}
+
+		exponentType, exponentBytes, _ := memberBytes(t, "exponent", b)
+		exponent := d.Exponent()
+		if exponentType.Presence == Constant {
+			var constExponent int32
+			if _, err := fmt.Sscan(exponentType.ConstValue, &constExponent); err != nil {
+				return err
+			}
+			exponent = constExponent
+		} else if exponent > 0 {
+			exponent = 0
+		}
+
+		mantissa := d.Shift(-exponent)
+		if !mantissa.Equal(mantissa.Truncate(0)) {
+			return fmt.Errorf("%v cannot be encoded with exponent %v", value, exponent)
+		}
+
+		mantissaType, mantissaBytes, _ := memberBytes(t, "mantissa", b)
+		if err := c.encodeValue(mantissaType, "", mantissaBytes, mantissa.String()); err != nil {
+			return err
+		}
+
+		if exponentType.Presence != Constant {
+			writeRaw(exponentType.PrimitiveType, exponentBytes, uint64(exponent), c.Schema.ByteOrder)
+		}
+		return nil
+
+	case isTimestamp(t):
+		var timestamp quickfix.FIXUTCTimestamp
+		if err := timestamp.Read([]byte(value)); err != nil {
+			return err
+		}
+
+		unit, err := c.readMember(t, "unit", b)
+		if err != nil {
+			return err
+		}
+
+		scale, _, err := timeUnit(unit)
+		if err != nil {
+			return err
+		}
+
+		timeType, timeBytes, _ := memberBytes(t, "time", b)
+		writeRaw(timeType.PrimitiveType, timeBytes, uint64(timestamp.UnixNano()/scale), c.Schema.ByteOrder)
+		return nil
+
+	case isMonthYear(t):
+		var monthYear quickfix.FIXMonthYear
+		if err := monthYear.Read([]byte(value)); err != nil {
+			return err
+		}
+
+		for name, value := range map[string]int{"year": monthYear.Year, "month": int(monthYear.Month), "day": monthYear.Day, "week": monthYear.Week} {
+			memberType, memberBytes, ok := memberBytes(t, name, b)
+			if !ok {
+				continue
+			}
+
+			raw := uint64(value)
+			if value == 0 && (name == "day" || name == "week") {
+				raw = nullRaw(memberType)
+			}
+			writeRaw(memberType.PrimitiveType, memberBytes, raw, c.Schema.ByteOrder)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("composite %v is not supported", t.Name)
+}
+
+//choiceValues returns the FIX value of each choice of the set of field
+func (c Codec) choiceValues(field *FieldDef) ([]string, error) {
+	if c.DataDictionary == nil {
+		return nil, fmt.Errorf("set %v requires a DataDictionary", field.Type.Name)
+	}
+
+	fieldType, ok := c.DataDictionary.FieldTypeByTag[field.ID]
+	if !ok {
+		return nil, fmt.Errorf("tag %v is not defined in the DataDictionary", field.ID)
+	}
+
+	valueByDescription := make(map[string]string, len(fieldType.Enums))
+	for _, enum := range fieldType.Enums {
+		valueByDescription[choiceKey(enum.Description)] = enum.Value
+	}
+
+	values := make([]string, len(field.Type.Choices))
+	for i, choice := range field.Type.Choices {
+		if values[i], ok = valueByDescription[choiceKey(choice.Name)]; !ok {
+			return nil, fmt.Errorf("choice %v of %v is not a value of tag %v", choice.Name, field.Type.Name, field.ID)
+		}
+	}
+
+	return values, nil
+}
+
+//choiceKey returns name without case or underscores, matching choice names to enum descriptions
+func choiceKey(name string) string {
+	return strings.ToUpper(strings.Replace(name, "_", "", -1))
+}
+
+//decodeSet returns the FIX multiple value of the set field encoded in b, false if no choice is set
+func (c Codec) decodeSet(field *FieldDef, b []byte) (string, bool, error) {
+	values, err := c.choiceValues(field)
+	if err != nil {
+		return "", false, err
+	}
+
+	raw := readRaw(field.Type.PrimitiveType, b, c.Schema.ByteOrder)
+	var set []string
+	for i, choice := range field.Type.Choices {
+		if raw&(1<<choice.Bit) != 0 {
+			set = append(set, values[i])
+		}
+	}
+
+	return strings.Join(set, " "), len(set) > 0, nil
+}
+
+//encodeSet encodes the FIX multiple value of the set field into b
+func (c Codec) encodeSet(field *FieldDef, b []byte, value string) error {
+	values, err := c.choiceValues(field)
+	if err != nil {
+		return err
+	}
+
+	var raw uint64
+values:
+	for _, v := range strings.Fields(value) {
+		for i, choice := range field.Type.Choices {
+			if values[i] == v {
+				raw |= 1 << choice.Bit
+				continue values
+			}
+		}
+		return fmt.Errorf("%v is not a choice of %v", v, field.Type.Name)
+	}
+
+	writeRaw(field.Type.PrimitiveType, b, raw, c.Schema.ByteOrder)
+	return nil
+}
+
+//encodeNull encodes the null value of t into b, failing if t is required
+func (c Codec) encodeNull(t *Type, b []byte) error {
+	switch t.Kind {
+	case Simple:
+		if t.PrimitiveType == "char" && t.Length > 1 {
+			return nil
+		}
+
+		if t.Presence == Required {
+			return fmt.Errorf("required value of %v missing", t.Name)
+		}
+
+		writeRaw(t.PrimitiveType, b, nullRaw(t), c.Schema.ByteOrder)
+		return nil
+
+	case Enum:
+		writeRaw(t.PrimitiveType, b, nullRaw(t), c.Schema.ByteOrder)
+		return nil
+
+	case Set:
+		return nil
+	}
+
+	var name string
+	switch {
+	case isDecimal(t):
+		name = "mantissa"
+	case isTimestamp(t):
+		name = "time"
+	case isMonthYear(t):
+		for _, name := range []string{"year", "month", "day", "week"} {
+			if member, memberBytes, ok := memberBytes(t, name, b); ok && member.Presence != Constant {
+				writeRaw(member.PrimitiveType, memberBytes, nullRaw(member), c.Schema.ByteOrder)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("composite %v is not supported", t.Name)
+	}
+
+	member, memberBytes, _ := memberBytes(t, name, b)
+	if member.Presence != Optional {
+		return fmt.Errorf("required value of %v missing", t.Name)
+	}
+
+	writeRaw(member.PrimitiveType, memberBytes, nullRaw(member), c.Schema.ByteOrder)
+	return nil
+}
+
+func isDecimal(t *Type) bool {
+	_, mantissa := t.Member("mantissa")
+	_, exponent := t.Member("exponent")
+	return mantissa && exponent
+}
+
+func isTimestamp(t *Type) bool {
+	_, time := t.Member("time")
+	_, unit := t.Member("unit")
+	return time && unit
+}
+
+func isMonthYear(t *Type) bool {
+	_, year := t.Member("year")
+	_, month := t.Member("month")
+	return year && month
+}
+
+func isDateSemanticType(semanticType string) bool {
+	switch semanticType {
+	case "LocalMktDate", "UTCDateOnly", "UTCDate":
+		return true
+	}
+
+	return false
+}
+
+//timeUnit returns the nanoseconds and FIX precision of an SBE time unit
+func timeUnit(unit int) (int64, quickfix.TimestampPrecision, error) {
+	switch unit {
+	case 0:
+		return int64(time.Second), quickfix.Seconds, nil
+	case 3:
+		return int64(time.Millisecond), quickfix.Millis, nil
+	case 6:
+		return int64(time.Microsecond), quickfix.Micros, nil
+	case 9:
+		return 1, quickfix.Nanos, nil
+	}
+
+	return 0, quickfix.Seconds, fmt.Errorf("unknown time unit %v", unit)
+}
+
+//decimalString returns the FIX value of mantissa * 10^exponent
+func decimalString(mantissa, exponent string) (string, bool, error) {
+	m, err := decimal.NewFromString(mantissa)
+	if err != nil {
+		return "", false, err
+	}
+
+	var e int32
+	if _, err := fmt.Sscan(exponent, &e); err != nil {
+		return "", false, err
+	}
+
+	return m.Shift(e).String(), true, nil
+}
@@ -0,0 +1,301 @@
+package sbe
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/quickfixgo/quickfix"
+	"github.com/quickfixgo/quickfix/datadictionary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exampleCodec(t *testing.T) *Codec {
+	return newTestCodec(t, "../_test_data/sbe/example-schema.xml")
+}
+
+//extensionCodec uses version 1 of the example schema, which appends ExecInst and Text to NewOrderSingle, with the FIX 5.0 SP2
+//DataDictionary defining its values
+func extensionCodec(t *testing.T) *Codec {
+	codec := newTestCodec(t, "../_test_data/sbe/extension-schema.xml")
+
+	var err error
+	codec.DataDictionary, err = datadictionary.Parse("../spec/FIX50SP2.xml")
+	require.Nil(t, err)
+	return codec
+}
+
+func newTestCodec(t *testing.T, path string) *Codec {
+	schema, err := Parse(path)
+	require.Nil(t, err)
+
+	codec := NewCodec(schema)
+	codec.BeginString = "FIXT.1.1"
+	return codec
+}
+
+func newExampleMessage(msgType string) *quickfix.Message {
+	msg := quickfix.NewMessage()
+	msg.Header.SetString(tagBeginString, "FIXT.1.1")
+	msg.Header.SetString(tagMsgType, msgType)
+	return msg
+}
+
+func newOrderSingle() *quickfix.Message {
+	msg := newExampleMessage("D")
+	msg.Body.SetString(11, "ORD00001")
+	msg.Body.SetString(1, "ACCT01")
+	msg.Body.SetString(55, "GEM4")
+	msg.Body.SetString(54, "1")
+	msg.Body.SetString(60, "20161219-14:30:00.123456789")
+	msg.Body.SetString(38, "7")
+	msg.Body.SetString(40, "2")
+	msg.Body.SetString(44, "99.125")
+	return msg
+}
+
+func executionReport() *quickfix.Message {
+	msg := newExampleMessage("8")
+	msg.Body.SetString(37, "O0000001")
+	msg.Body.SetString(17, "EXEC0001")
+	msg.Body.SetString(150, "F")
+	msg.Body.SetString(39, "2")
+	msg.Body.SetString(55, "GEM4")
+	msg.Body.SetString(200, "201612")
+	msg.Body.SetString(54, "1")
+	msg.Body.SetString(151, "0")
+	msg.Body.SetString(14, "7")
+	msg.Body.SetString(75, "20161219")
+
+	fills := quickfix.NewRepeatingGroup(1362, quickfix.GroupTemplate{quickfix.GroupElement(1364), quickfix.GroupElement(1365)})
+	fills.Add().SetString(1364, "99.125").SetString(1365, "3")
+	fills.Add().SetString(1364, "100.25").SetString(1365, "4")
+	msg.Body.SetGroup(fills)
+	return msg
+}
+
+func businessMessageReject() *quickfix.Message {
+	msg := newExampleMessage("j")
+	msg.Body.SetString(379, "ORD00001")
+	msg.Body.SetString(380, "2")
+	msg.Body.SetString(58, "Unknown symbol")
+	return msg
+}
+
+//golden encodings of example-schema.xml, which follows the layout of the business message examples of the SBE
+//specification. The bytes are derived by hand from the schema, see TestGoldenNewOrderSingleLayout.
+var (
+	goldenNewOrderSingle = []byte{
+		0x36, 0x00, 0x63, 0x00, 0x5b, 0x00, 0x00, 0x00, //header
+		'O', 'R', 'D', '0', '0', '0', '0', '1', //ClOrdId
+		'A', 'C', 'C', 'T', '0', '1', 0x00, 0x00, //Account
+		'G', 'E', 'M', '4', 0x00, 0x00, 0x00, 0x00, //Symbol
+		'1',                                            //Side
+		0x15, 0xdd, 0x6e, 0x99, 0xaa, 0xae, 0x91, 0x14, //TransactTime
+		0x07, 0x00, 0x00, 0x00, //OrderQty
+		'2',                                            //OrdType
+		0x35, 0x83, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, //Price
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x80, //StopPx
+	}
+
+	goldenExecutionReport = []byte{
+		0x2a, 0x00, 0x62, 0x00, 0x5b, 0x00, 0x00, 0x00, //header
+		'O', '0', '0', '0', '0', '0', '0', '1', //OrderID
+		'E', 'X', 'E', 'C', '0', '0', '0', '1', //ExecID
+		'F',                                        //ExecType
+		'2',                                        //OrdStatus
+		'G', 'E', 'M', '4', 0x00, 0x00, 0x00, 0x00, //Symbol
+		0xe0, 0x07, 0x0c, 0xff, 0xff, //MaturityMonthYear
+		'1',                    //Side
+		0x00, 0x00, 0x00, 0x00, //LeavesQty
+		0x07, 0x00, 0x00, 0x00, //CumQty
+		0x02, 0x43, //TradeDate
+		0x0c, 0x00, 0x02, 0x00, //FillsGrp dimension
+		0x35, 0x83, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, 0x00, 0x00, 0x00, //FillPx, FillQty
+		0x9a, 0x87, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, //FillPx, FillQty
+	}
+
+	goldenBusinessMessageReject = []byte{
+		0x09, 0x00, 0x61, 0x00, 0x5b, 0x00, 0x00, 0x00, //header
+		'O', 'R', 'D', '0', '0', '0', '0', '1', //BusinesRejectRefId
+		0x02,       //BusinessRejectReason
+		0x0e, 0x00, //Text length
+		'U', 'n', 'k', 'n', 'o', 'w', 'n', ' ', 's', 'y', 'm', 'b', 'o', 'l',
+	}
+)
+
+func TestCodecGolden(t *testing.T) {
+	codec := exampleCodec(t)
+
+	var tests = []struct {
+		name   string
+		msg    *quickfix.Message
+		golden []byte
+	}{
+		{"NewOrderSingle", newOrderSingle(), goldenNewOrderSingle},
+		{"ExecutionReport", executionReport(), goldenExecutionReport},
+		{"BusinessMessageReject", businessMessageReject(), goldenBusinessMessageReject},
+	}
+
+	for _, test := range tests {
+		encoded, err := codec.Encode(test.msg)
+		require.Nil(t, err, test.name)
+		assert.Equal(t, test.golden, encoded, test.name)
+
+		decoded := quickfix.NewMessage()
+		n, err := codec.Decode(decoded, append(test.golden, 0xff))
+		require.Nil(t, err, test.name)
+		assert.Equal(t, len(test.golden), n, test.name)
+		assert.Equal(t, test.msg.String(), decoded.String(), test.name)
+	}
+}
+
+func TestCodecRoutesDecodedMessages(t *testing.T) {
+	codec := exampleCodec(t)
+
+	var routed bool
+	router := quickfix.NewMessageRouter()
+	router.AddRoute("FIXT.1.1", "8", func(msg *quickfix.Message, sessionID quickfix.SessionID) quickfix.MessageRejectError {
+		routed = true
+		return nil
+	})
+
+	msg := quickfix.NewMessage()
+	_, err := codec.Decode(msg, goldenExecutionReport)
+	require.Nil(t, err)
+	msg.Header.SetString(1128, "FIXT.1.1")
+
+	assert.Nil(t, router.Route(msg, quickfix.SessionID{}))
+	assert.True(t, routed)
+}
+
+func TestGoldenNewOrderSingleLayout(t *testing.T) {
+	order := binary.LittleEndian
+	golden := make([]byte, 8+54)
+
+	order.PutUint16(golden[0:], 54)
+	order.PutUint16(golden[2:], 99)
+	order.PutUint16(golden[4:], 91)
+	order.PutUint16(golden[6:], 0)
+
+	block := golden[8:]
+	copy(block[0:8], "ORD00001")
+	copy(block[8:16], "ACCT01")
+	copy(block[16:24], "GEM4")
+	block[24] = '1'
+	order.PutUint64(block[25:], uint64(time.Date(2016, time.December, 19, 14, 30, 0, 123456789, time.UTC).UnixNano()))
+	order.PutUint32(block[33:], 7)
+	block[37] = '2'
+	order.PutUint64(block[38:], 99125)
+	order.PutUint64(block[46:], 1<<63) //null price
+
+	assert.Equal(t, golden, goldenNewOrderSingle)
+}
+
+//goldenNewOrderSingleV1 is goldenNewOrderSingle in version 1 of the schema, with the ExecInst choices DoNotIncrease and
+//DoNotReduce and the Text "hi"
+var goldenNewOrderSingleV1 = append(append([]byte{0x37, 0x00, 0x63, 0x00, 0x5b, 0x00, 0x01, 0x00}, goldenNewOrderSingle[8:]...), 0x06, 0x02, 0x00, 'h', 'i')
+
+func TestCodecSchemaExtension(t *testing.T) {
+	codec := extensionCodec(t)
+
+	msg := newOrderSingle()
+	msg.Body.SetString(18, "E F")
+	msg.Body.SetString(58, "hi")
+
+	encoded, err := codec.Encode(msg)
+	require.Nil(t, err)
+	assert.Equal(t, goldenNewOrderSingleV1, encoded)
+
+	decoded := quickfix.NewMessage()
+	n, err := codec.Decode(decoded, goldenNewOrderSingleV1)
+	require.Nil(t, err)
+	assert.Equal(t, len(goldenNewOrderSingleV1), n)
+	assert.Equal(t, msg.String(), decoded.String())
+
+	//a version 0 message has neither ExecInst nor Text, the bytes that follow belong to the next message
+	stream := append(append([]byte(nil), goldenNewOrderSingle...), goldenNewOrderSingleV1...)
+	decoded = quickfix.NewMessage()
+	n, err = codec.Decode(decoded, stream)
+	require.Nil(t, err)
+	assert.Equal(t, len(goldenNewOrderSingle), n)
+	assert.False(t, decoded.Body.Has(18))
+	assert.False(t, decoded.Body.Has(58))
+	assert.True(t, decoded.Body.Has(44))
+
+	n, err = codec.Decode(decoded, goldenNewOrderSingle)
+	require.Nil(t, err)
+	assert.Equal(t, len(goldenNewOrderSingle), n)
+
+	msg.Body.SetString(18, "E G")
+	_, err = codec.Encode(msg)
+	assert.NotNil(t, err, "value without choice")
+
+	codec.DataDictionary = nil
+	_, err = codec.Decode(quickfix.NewMessage(), goldenNewOrderSingleV1)
+	assert.NotNil(t, err, "set without DataDictionary")
+}
+
+func TestCodecDecodeSetFIXValues(t *testing.T) {
+	codec := extensionCodec(t)
+
+	decoded := quickfix.NewMessage()
+	_, err := codec.Decode(decoded, goldenNewOrderSingleV1)
+	require.Nil(t, err)
+
+	var execInst quickfix.FIXMultipleValueString
+	require.Nil(t, decoded.Body.GetField(18, &execInst))
+	assert.Equal(t, quickfix.FIXMultipleValueString{"E", "F"}, execInst)
+}
+
+func TestCodecEncodeErrors(t *testing.T) {
+	codec := exampleCodec(t)
+
+	var tests = []struct {
+		name  string
+		setup func(*quickfix.Message)
+	}{
+		{"undefined field", func(msg *quickfix.Message) { msg.Body.SetString(5000, "custom") }},
+		{"invalid enum", func(msg *quickfix.Message) { msg.Body.SetString(54, "9") }},
+		{"string too long", func(msg *quickfix.Message) { msg.Body.SetString(11, "ORD000001") }},
+		{"inexact price", func(msg *quickfix.Message) { msg.Body.SetString(44, "99.1255") }},
+		{"invalid quantity", func(msg *quickfix.Message) { msg.Body.SetString(38, "seven") }},
+		{"unknown message", func(msg *quickfix.Message) { msg.Header.SetString(tagMsgType, "F") }},
+	}
+
+	for _, test := range tests {
+		msg := newOrderSingle()
+		test.setup(msg)
+
+		_, err := codec.Encode(msg)
+		assert.NotNil(t, err, test.name)
+	}
+}
+
+func TestCodecDecodeErrors(t *testing.T) {
+	codec := exampleCodec(t)
+
+	wrongSchema := append([]byte(nil), goldenNewOrderSingle...)
+	wrongSchema[4] = 0x5c
+
+	unknownTemplate := append([]byte(nil), goldenNewOrderSingle...)
+	unknownTemplate[2] = 0x01
+
+	var tests = []struct {
+		name string
+		data []byte
+	}{
+		{"short header", goldenNewOrderSingle[:4]},
+		{"short block", goldenNewOrderSingle[:20]},
+		{"short group", goldenExecutionReport[:len(goldenExecutionReport)-1]},
+		{"short data", goldenBusinessMessageReject[:len(goldenBusinessMessageReject)-1]},
+		{"wrong schema", wrongSchema},
+		{"unknown template", unknownTemplate},
+	}
+
+	for _, test := range tests {
+		_, err := codec.Decode(quickfix.NewMessage(), test.data)
+		assert.NotNil(t, err, test.name)
+	}
+}
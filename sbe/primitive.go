@@ -0,0 +1,158 @@
+package sbe
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+func primitiveSize(primitiveType string) int {
+	switch primitiveType {
+	case "char", "int8", "uint8":
+		return 1
+	case "int16", "uint16":
+		return 2
+	case "int32", "uint32", "float":
+		return 4
+	case "int64", "uint64", "double":
+		return 8
+	}
+
+	return 0
+}
+
+func isSigned(primitiveType string) bool {
+	switch primitiveType {
+	case "int8", "int16", "int32", "int64":
+		return true
+	}
+
+	return false
+}
+
+func isFloat(primitiveType string) bool {
+	return primitiveType == "float" || primitiveType == "double"
+}
+
+//readRaw returns the bits of the primitive at the start of b
+func readRaw(primitiveType string, b []byte, order binary.ByteOrder) uint64 {
+	switch primitiveSize(primitiveType) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(order.Uint16(b))
+	case 4:
+		return uint64(order.Uint32(b))
+	}
+
+	return order.Uint64(b)
+}
+
+//writeRaw writes the bits of the primitive to the start of b
+func writeRaw(primitiveType string, b []byte, raw uint64, order binary.ByteOrder) {
+	switch primitiveSize(primitiveType) {
+	case 1:
+		b[0] = byte(raw)
+	case 2:
+		order.PutUint16(b, uint16(raw))
+	case 4:
+		order.PutUint32(b, uint32(raw))
+	default:
+		order.PutUint64(b, raw)
+	}
+}
+
+//signExtend returns the signed value of the bits of a signed primitive
+func signExtend(primitiveType string, raw uint64) int64 {
+	switch primitiveSize(primitiveType) {
+	case 1:
+		return int64(int8(raw))
+	case 2:
+		return int64(int16(raw))
+	case 4:
+		return int64(int32(raw))
+	}
+
+	return int64(raw)
+}
+
+//nullRaw returns the bits of the null value of t, the SBE default for the primitive type unless t defines a nullValue
+func nullRaw(t *Type) uint64 {
+	if t.NullValue != "" {
+		if raw, err := parseRaw(t.PrimitiveType, t.NullValue); err == nil {
+			return raw
+		}
+	}
+
+	switch t.PrimitiveType {
+	case "char":
+		return 0
+	case "float":
+		return uint64(math.Float32bits(float32(math.NaN())))
+	case "double":
+		return math.Float64bits(math.NaN())
+	}
+
+	bits := uint(primitiveSize(t.PrimitiveType) * 8)
+	if isSigned(t.PrimitiveType) {
+		return 1 << (bits - 1)
+	}
+
+	return 1<<bits - 1
+}
+
+//isNull returns true if raw is the null value of t
+func isNull(t *Type, raw uint64) bool {
+	switch t.PrimitiveType {
+	case "float":
+		return math.IsNaN(float64(math.Float32frombits(uint32(raw))))
+	case "double":
+		return math.IsNaN(math.Float64frombits(raw))
+	}
+
+	return raw == nullRaw(t)
+}
+
+//formatRaw returns the FIX value of the bits of a primitive
+func formatRaw(primitiveType string, raw uint64) string {
+	switch {
+	case primitiveType == "char":
+		return string([]byte{byte(raw)})
+	case primitiveType == "float":
+		return strconv.FormatFloat(float64(math.Float32frombits(uint32(raw))), 'f', -1, 32)
+	case primitiveType == "double":
+		return strconv.FormatFloat(math.Float64frombits(raw), 'f', -1, 64)
+	case isSigned(primitiveType):
+		return strconv.FormatInt(signExtend(primitiveType, raw), 10)
+	}
+
+	return strconv.FormatUint(raw, 10)
+}
+
+//parseRaw returns the bits of a primitive holding the FIX value
+func parseRaw(primitiveType string, value string) (uint64, error) {
+	bits := primitiveSize(primitiveType) * 8
+
+	switch {
+	case primitiveType == "char":
+		if len(value) != 1 {
+			return 0, fmt.Errorf("invalid char value %v", value)
+		}
+		return uint64(value[0]), nil
+
+	case primitiveType == "float":
+		f, err := strconv.ParseFloat(value, 32)
+		return uint64(math.Float32bits(float32(f))), err
+
+	case primitiveType == "double":
+		f, err := strconv.ParseFloat(value, 64)
+		return math.Float64bits(f), err
+
+	case isSigned(primitiveType):
+		i, err := strconv.ParseInt(value, 10, bits)
+		return uint64(i) & (1<<uint(bits) - 1), err
+	}
+
+	return strconv.ParseUint(value, 10, bits)
+}
@@ -0,0 +1,628 @@
+//Package sbe provides support for Simple Binary Encoding (SBE) message schemas and a Codec mapping SBE messages to
+//and from quickfix messages
+package sbe
+
+import (
+	"encoding/binary"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+//TypeKind distinguishes the kinds of Type in a Schema
+type TypeKind int
+
+//All TypeKinds defined by SBE
+const (
+	Simple TypeKind = iota
+	Composite
+	Enum
+	Set
+)
+
+//Presence of a Type or FieldDef
+type Presence int
+
+//All Presences defined by SBE
+const (
+	Required Presence = iota
+	Optional
+	Constant
+)
+
+//Type is a simple type, composite, enum or set of a Schema
+type Type struct {
+	Name string
+	Kind TypeKind
+
+	//PrimitiveType is the primitive type of a simple type, or the encoding type of an enum or set
+	PrimitiveType string
+
+	//Length is the number of primitive values of a simple type, more than one for arrays such as strings
+	Length int
+
+	Presence     Presence
+	NullValue    string
+	ConstValue   string
+	SemanticType string
+
+	//Offset of a composite member within the composite, -1 if it follows the previous member
+	Offset int
+
+	//Members of a composite
+	Members []*Type
+
+	//ValidValues of an enum
+	ValidValues []ValidValue
+
+	//Choices of a set
+	Choices []Choice
+}
+
+//ValidValue is a value of an enum
+type ValidValue struct {
+	Name  string
+	Value string
+}
+
+//Choice is a bit of a set
+type Choice struct {
+	Name string
+	Bit  uint
+}
+
+//Size returns the number of bytes encoding t in a block
+func (t *Type) Size() int {
+	if t.Presence == Constant {
+		return 0
+	}
+
+	switch t.Kind {
+	case Composite:
+		var size int
+		for _, member := range t.Members {
+			if member.Offset >= 0 {
+				size = member.Offset
+			}
+			size += member.Size()
+		}
+		return size
+	}
+
+	return primitiveSize(t.PrimitiveType) * t.Length
+}
+
+//Member returns the composite member named name
+func (t *Type) Member(name string) (*Type, bool) {
+	for _, member := range t.Members {
+		if member.Name == name {
+			return member, true
+		}
+	}
+
+	return nil, false
+}
+
+//FieldDef is a field or variable length data field of a message or repeating group. The ID of the field is its FIX
+//tag.
+type FieldDef struct {
+	Name         string
+	ID           int
+	Type         *Type
+	Offset       int
+	Presence     Presence
+	ConstValue   string
+	SemanticType string
+
+	//SinceVersion is the version of the schema that added the field
+	SinceVersion int
+}
+
+//Block holds the fields, repeating groups and variable length data of a message or repeating group
+type Block struct {
+	BlockLength int
+	Fields      []*FieldDef
+	Groups      []*GroupDef
+	Data        []*FieldDef
+}
+
+//GroupDef is a repeating group. The ID of the group is the tag of its FIX NumInGroup field.
+type GroupDef struct {
+	Block
+	Name          string
+	ID            int
+	DimensionType *Type
+
+	//SinceVersion is the version of the schema that added the group
+	SinceVersion int
+}
+
+//MessageDef is a message of a Schema. The SemanticType of the message is its FIX MsgType.
+type MessageDef struct {
+	Block
+	Name         string
+	ID           int
+	SemanticType string
+}
+
+//Schema is an SBE message schema
+type Schema struct {
+	Package         string
+	ID              int
+	Version         int
+	SemanticVersion string
+	ByteOrder       binary.ByteOrder
+	HeaderType      *Type
+	Types           map[string]*Type
+	Messages        []*MessageDef
+
+	messageByID      map[int]*MessageDef
+	messageByMsgType map[string]*MessageDef
+}
+
+//MessageByID returns the message with template id
+func (s *Schema) MessageByID(id int) (*MessageDef, bool) {
+	m, ok := s.messageByID[id]
+	return m, ok
+}
+
+//MessageByMsgType returns the first message with the semantic type msgType
+func (s *Schema) MessageByMsgType(msgType string) (*MessageDef, bool) {
+	m, ok := s.messageByMsgType[msgType]
+	return m, ok
+}
+
+//Parse loads and builds a Schema from an SBE message schema xml file
+func Parse(path string) (*Schema, error) {
+	xmlFile, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("problem opening file: %v", path)
+	}
+	defer xmlFile.Close()
+
+	return ParseSrc(xmlFile)
+}
+
+//ParseSrc loads and builds a Schema from an SBE message schema xml source
+func ParseSrc(xmlSrc io.Reader) (*Schema, error) {
+	doc := new(xmlSchema)
+	decoder := xml.NewDecoder(xmlSrc)
+	if err := decoder.Decode(doc); err != nil {
+		return nil, fmt.Errorf("problem parsing schema: %v", err)
+	}
+
+	b := schemaBuilder{doc: doc, xmlTypes: make(map[string]*xmlType)}
+	return b.build()
+}
+
+type xmlSchema struct {
+	Package         string       `xml:"package,attr"`
+	ID              int          `xml:"id,attr"`
+	Version         int          `xml:"version,attr"`
+	SemanticVersion string       `xml:"semanticVersion,attr"`
+	ByteOrder       string       `xml:"byteOrder,attr"`
+	HeaderType      string       `xml:"headerType,attr"`
+	Types           []xmlTypes   `xml:"types"`
+	Messages        []*xmlMember `xml:"message"`
+}
+
+type xmlTypes struct {
+	Types []*xmlType `xml:",any"`
+}
+
+//xmlType represents type, composite, enum, set and ref elements, and the validValue and choice elements of enums and
+//sets
+type xmlType struct {
+	XMLName       xml.Name
+	Name          string     `xml:"name,attr"`
+	PrimitiveType string     `xml:"primitiveType,attr"`
+	EncodingType  string     `xml:"encodingType,attr"`
+	Length        string     `xml:"length,attr"`
+	Presence      string     `xml:"presence,attr"`
+	NullValue     string     `xml:"nullValue,attr"`
+	SemanticType  string     `xml:"semanticType,attr"`
+	Offset        string     `xml:"offset,attr"`
+	Type          string     `xml:"type,attr"`
+	Value         string     `xml:",chardata"`
+	Members       []*xmlType `xml:",any"`
+}
+
+//xmlMember represents field, group and data elements of messages and groups
+type xmlMember struct {
+	XMLName       xml.Name
+	Name          string       `xml:"name,attr"`
+	ID            string       `xml:"id,attr"`
+	Type          string       `xml:"type,attr"`
+	Offset        string       `xml:"offset,attr"`
+	BlockLength   string       `xml:"blockLength,attr"`
+	SemanticType  string       `xml:"semanticType,attr"`
+	Presence      string       `xml:"presence,attr"`
+	ValueRef      string       `xml:"valueRef,attr"`
+	DimensionType string       `xml:"dimensionType,attr"`
+	SinceVersion  string       `xml:"sinceVersion,attr"`
+	Members       []*xmlMember `xml:",any"`
+}
+
+type schemaBuilder struct {
+	doc      *xmlSchema
+	schema   *Schema
+	xmlTypes map[string]*xmlType
+}
+
+func (b *schemaBuilder) build() (*Schema, error) {
+	b.schema = &Schema{
+		Package:          b.doc.Package,
+		ID:               b.doc.ID,
+		Version:          b.doc.Version,
+		SemanticVersion:  b.doc.SemanticVersion,
+		ByteOrder:        binary.LittleEndian,
+		Types:            make(map[string]*Type),
+		messageByID:      make(map[int]*MessageDef),
+		messageByMsgType: make(map[string]*MessageDef),
+	}
+
+	switch b.doc.ByteOrder {
+	case "", "littleEndian":
+	case "bigEndian":
+		b.schema.ByteOrder = binary.BigEndian
+	default:
+		return nil, fmt.Errorf("unknown byteOrder %v", b.doc.ByteOrder)
+	}
+
+	for _, types := range b.doc.Types {
+		for _, t := range types.Types {
+			b.xmlTypes[t.Name] = t
+		}
+	}
+
+	for name := range b.xmlTypes {
+		if _, err := b.findOrBuildType(name); err != nil {
+			return nil, err
+		}
+	}
+
+	headerType := b.doc.HeaderType
+	if headerType == "" {
+		headerType = "messageHeader"
+	}
+
+	var ok bool
+	if b.schema.HeaderType, ok = b.schema.Types[headerType]; !ok {
+		return nil, newUnknownType(headerType)
+	}
+
+	for _, m := range b.doc.Messages {
+		msg := &MessageDef{Name: m.Name, SemanticType: m.SemanticType}
+
+		var err error
+		if msg.ID, err = strconv.Atoi(m.ID); err != nil {
+			return nil, fmt.Errorf("message %v: invalid id %v", m.Name, m.ID)
+		}
+
+		if err := b.buildBlock(&msg.Block, m); err != nil {
+			return nil, fmt.Errorf("message %v: %v", m.Name, err)
+		}
+
+		b.schema.Messages = append(b.schema.Messages, msg)
+		b.schema.messageByID[msg.ID] = msg
+		if _, ok := b.schema.messageByMsgType[msg.SemanticType]; !ok && msg.SemanticType != "" {
+			b.schema.messageByMsgType[msg.SemanticType] = msg
+		}
+	}
+
+	return b.schema, nil
+}
+
+func (b *schemaBuilder) findOrBuildType(name string) (*Type, error) {
+	if t, ok := b.schema.Types[name]; ok {
+		return t, nil
+	}
+
+	if primitiveSize(name) > 0 {
+		return &Type{Name: name, Kind: Simple, PrimitiveType: name, Length: 1, Offset: -1}, nil
+	}
+
+	xmlT, ok := b.xmlTypes[name]
+	if !ok {
+		return nil, newUnknownType(name)
+	}
+
+	t, err := b.buildType(xmlT)
+	if err != nil {
+		return nil, err
+	}
+
+	b.schema.Types[name] = t
+	return t, nil
+}
+
+func (b *schemaBuilder) buildType(xmlT *xmlType) (*Type, error) {
+	t := &Type{
+		Name:         xmlT.Name,
+		Length:       1,
+		NullValue:    xmlT.NullValue,
+		SemanticType: xmlT.SemanticType,
+		Offset:       -1,
+	}
+
+	var err error
+	if t.Presence, err = parsePresence(xmlT.Presence); err != nil {
+		return nil, err
+	}
+
+	if xmlT.Offset != "" {
+		if t.Offset, err = strconv.Atoi(xmlT.Offset); err != nil {
+			return nil, fmt.Errorf("type %v: invalid offset %v", xmlT.Name, xmlT.Offset)
+		}
+	}
+
+	switch xmlT.XMLName.Local {
+	case "type":
+		t.Kind = Simple
+		t.PrimitiveType = xmlT.PrimitiveType
+		if primitiveSize(t.PrimitiveType) == 0 {
+			return nil, fmt.Errorf("type %v: unknown primitiveType %v", xmlT.Name, xmlT.PrimitiveType)
+		}
+
+		if xmlT.Length != "" {
+			if t.Length, err = strconv.Atoi(xmlT.Length); err != nil {
+				return nil, fmt.Errorf("type %v: invalid length %v", xmlT.Name, xmlT.Length)
+			}
+		}
+
+		if t.Presence == Constant {
+			t.ConstValue = strings.TrimSpace(xmlT.Value)
+		}
+
+	case "composite":
+		t.Kind = Composite
+		for _, xmlMember := range xmlT.Members {
+			member, err := b.buildMember(xmlMember)
+			if err != nil {
+				return nil, fmt.Errorf("composite %v: %v", xmlT.Name, err)
+			}
+			t.Members = append(t.Members, member)
+		}
+
+	case "enum":
+		t.Kind = Enum
+		if err := b.setEncodingType(t, xmlT.EncodingType); err != nil {
+			return nil, err
+		}
+
+		for _, v := range xmlT.Members {
+			t.ValidValues = append(t.ValidValues, ValidValue{Name: v.Name, Value: strings.TrimSpace(v.Value)})
+		}
+
+	case "set":
+		t.Kind = Set
+		if err := b.setEncodingType(t, xmlT.EncodingType); err != nil {
+			return nil, err
+		}
+
+		for _, c := range xmlT.Members {
+			bit, err := strconv.ParseUint(strings.TrimSpace(c.Value), 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("set %v: invalid choice %v", xmlT.Name, c.Name)
+			}
+			t.Choices = append(t.Choices, Choice{Name: c.Name, Bit: uint(bit)})
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown type element %v", xmlT.XMLName.Local)
+	}
+
+	return t, nil
+}
+
+//buildMember builds a composite member, resolving ref elements to a copy of the referenced type
+func (b *schemaBuilder) buildMember(xmlT *xmlType) (*Type, error) {
+	if xmlT.XMLName.Local != "ref" {
+		return b.buildType(xmlT)
+	}
+
+	ref, err := b.findOrBuildType(xmlT.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	member := *ref
+	member.Name = xmlT.Name
+	member.Offset = -1
+	if xmlT.Offset != "" {
+		if member.Offset, err = strconv.Atoi(xmlT.Offset); err != nil {
+			return nil, fmt.Errorf("ref %v: invalid offset %v", xmlT.Name, xmlT.Offset)
+		}
+	}
+
+	return &member, nil
+}
+
+//setEncodingType sets the primitive type of an enum or set from its encodingType, a primitive or simple type
+func (b *schemaBuilder) setEncodingType(t *Type, encodingType string) error {
+	encoding, err := b.findOrBuildType(encodingType)
+	if err != nil {
+		return err
+	}
+
+	if encoding.Kind != Simple {
+		return fmt.Errorf("%v: encodingType %v is not a simple type", t.Name, encodingType)
+	}
+
+	t.PrimitiveType = encoding.PrimitiveType
+	if t.NullValue == "" {
+		t.NullValue = encoding.NullValue
+	}
+
+	return nil
+}
+
+func (b *schemaBuilder) buildBlock(block *Block, m *xmlMember) error {
+	var err error
+	var offset int
+	for _, member := range m.Members {
+		switch member.XMLName.Local {
+		case "field":
+			var field *FieldDef
+			if field, err = b.buildFieldDef(member); err != nil {
+				return err
+			}
+
+			if member.Offset != "" {
+				if offset, err = strconv.Atoi(member.Offset); err != nil {
+					return fmt.Errorf("field %v: invalid offset %v", member.Name, member.Offset)
+				}
+			}
+			field.Offset = offset
+			offset += field.Size()
+
+			block.Fields = append(block.Fields, field)
+
+		case "group":
+			group := &GroupDef{Name: member.Name}
+			if group.ID, err = strconv.Atoi(member.ID); err != nil {
+				return fmt.Errorf("group %v: invalid id %v", member.Name, member.ID)
+			}
+
+			if group.SinceVersion, err = parseSinceVersion(member); err != nil {
+				return err
+			}
+
+			dimensionType := member.DimensionType
+			if dimensionType == "" {
+				dimensionType = "groupSizeEncoding"
+			}
+			if group.DimensionType, err = b.findOrBuildType(dimensionType); err != nil {
+				return err
+			}
+
+			if err = b.buildBlock(&group.Block, member); err != nil {
+				return fmt.Errorf("group %v: %v", member.Name, err)
+			}
+
+			block.Groups = append(block.Groups, group)
+
+		case "data":
+			var field *FieldDef
+			if field, err = b.buildFieldDef(member); err != nil {
+				return err
+			}
+
+			block.Data = append(block.Data, field)
+
+		default:
+			return fmt.Errorf("unknown element %v", member.XMLName.Local)
+		}
+	}
+
+	block.BlockLength = offset
+	if m.BlockLength != "" {
+		if block.BlockLength, err = strconv.Atoi(m.BlockLength); err != nil {
+			return fmt.Errorf("invalid blockLength %v", m.BlockLength)
+		}
+	}
+
+	return nil
+}
+
+func (b *schemaBuilder) buildFieldDef(m *xmlMember) (*FieldDef, error) {
+	field := &FieldDef{Name: m.Name, SemanticType: m.SemanticType}
+
+	var err error
+	if field.ID, err = strconv.Atoi(m.ID); err != nil {
+		return nil, fmt.Errorf("field %v: invalid id %v", m.Name, m.ID)
+	}
+
+	if field.Type, err = b.findOrBuildType(m.Type); err != nil {
+		return nil, fmt.Errorf("field %v: %v", m.Name, err)
+	}
+
+	if field.SinceVersion, err = parseSinceVersion(m); err != nil {
+		return nil, err
+	}
+
+	if field.SemanticType == "" {
+		field.SemanticType = field.Type.SemanticType
+	}
+
+	field.Presence = field.Type.Presence
+	if m.Presence != "" {
+		if field.Presence, err = parsePresence(m.Presence); err != nil {
+			return nil, err
+		}
+	}
+
+	if field.Presence == Constant {
+		field.ConstValue = field.Type.ConstValue
+		if m.ValueRef != "" {
+			if field.ConstValue, err = b.valueRef(m.ValueRef); err != nil {
+				return nil, fmt.Errorf("field %v: %v", m.Name, err)
+			}
+		}
+	}
+
+	return field, nil
+}
+
+//valueRef resolves a constant value reference of the form enumName.validValueName
+func (b *schemaBuilder) valueRef(ref string) (string, error) {
+	i := strings.LastIndexByte(ref, '.')
+	if i < 0 {
+		return "", fmt.Errorf("invalid valueRef %v", ref)
+	}
+
+	t, err := b.findOrBuildType(ref[:i])
+	if err != nil {
+		return "", err
+	}
+
+	for _, v := range t.ValidValues {
+		if v.Name == ref[i+1:] {
+			return v.Value, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown valueRef %v", ref)
+}
+
+//Size returns the number of bytes encoding f in a block
+func (f *FieldDef) Size() int {
+	if f.Presence == Constant {
+		return 0
+	}
+
+	return f.Type.Size()
+}
+
+func parseSinceVersion(m *xmlMember) (int, error) {
+	if m.SinceVersion == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(m.SinceVersion)
+	if err != nil {
+		return 0, fmt.Errorf("%v: invalid sinceVersion %v", m.Name, m.SinceVersion)
+	}
+
+	return version, nil
+}
+
+func parsePresence(presence string) (Presence, error) {
+	switch presence {
+	case "", "required":
+		return Required, nil
+	case "optional":
+		return Optional, nil
+	case "constant":
+		return Constant, nil
+	}
+
+	return Required, fmt.Errorf("unknown presence %v", presence)
+}
+
+func newUnknownType(name string) error {
+	return fmt.Errorf("unknown type %v", name)
+}
@@ -0,0 +1,88 @@
+package sbe
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	schema, err := Parse("../_test_data/sbe/example-schema.xml")
+	require.Nil(t, err)
+
+	assert.Equal(t, 91, schema.ID)
+	assert.Equal(t, 0, schema.Version)
+	assert.Equal(t, binary.LittleEndian, schema.ByteOrder)
+	assert.Equal(t, 8, schema.HeaderType.Size())
+
+	var tests = []struct {
+		msgType     string
+		id          int
+		name        string
+		blockLength int
+	}{
+		{"j", 97, "BusinessMessageReject", 9},
+		{"8", 98, "ExecutionReport", 42},
+		{"D", 99, "NewOrderSingle", 54},
+	}
+
+	for _, test := range tests {
+		byMsgType, ok := schema.MessageByMsgType(test.msgType)
+		require.True(t, ok, test.name)
+		byID, ok := schema.MessageByID(test.id)
+		require.True(t, ok, test.name)
+
+		assert.Equal(t, byMsgType, byID, test.name)
+		assert.Equal(t, test.name, byID.Name)
+		assert.Equal(t, test.blockLength, byID.BlockLength, test.name)
+	}
+
+	order, _ := schema.MessageByID(99)
+	var offsets []int
+	for _, f := range order.Fields {
+		offsets = append(offsets, f.Offset)
+	}
+	assert.Equal(t, []int{0, 8, 16, 24, 25, 33, 37, 38, 46}, offsets)
+
+	report, _ := schema.MessageByID(98)
+	require.Len(t, report.Groups, 1)
+	assert.Equal(t, 1362, report.Groups[0].ID)
+	assert.Equal(t, 12, report.Groups[0].BlockLength)
+	assert.Equal(t, 4, report.Groups[0].DimensionType.Size())
+
+	reject, _ := schema.MessageByID(97)
+	require.Len(t, reject.Data, 1)
+	assert.Equal(t, 58, reject.Data[0].ID)
+}
+
+func TestParseSinceVersion(t *testing.T) {
+	schema, err := Parse("../_test_data/sbe/extension-schema.xml")
+	require.Nil(t, err)
+
+	order, ok := schema.MessageByID(99)
+	require.True(t, ok)
+	assert.Equal(t, 0, order.Fields[0].SinceVersion)
+	assert.Equal(t, 1, order.Fields[len(order.Fields)-1].SinceVersion)
+	require.Len(t, order.Data, 1)
+	assert.Equal(t, 1, order.Data[0].SinceVersion)
+}
+
+func TestParseSrcErrors(t *testing.T) {
+	var tests = []struct {
+		name string
+		src  string
+	}{
+		{"malformed", "<messageSchema"},
+		{"unknown type", `<messageSchema id="1"><types><composite name="messageHeader"><type name="blockLength" primitiveType="uint16"/></composite></types>
+<message name="M" id="1"><field name="F" id="1" type="missing"/></message></messageSchema>`},
+		{"missing header", `<messageSchema id="1"><types/></messageSchema>`},
+	}
+
+	for _, test := range tests {
+		_, err := ParseSrc(strings.NewReader(test.src))
+		assert.NotNil(t, err, test.name)
+	}
+}
@@ -2,8 +2,10 @@ package quickfix
 
 import (
 	"bytes"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
 	"sync"
 	"time"
 
@@ -21,6 +23,10 @@ type session struct {
 	messageOut chan<- []byte
 	messageIn  <-chan fixIn
 
+	//remote peer of the current connection
+	remoteAddr net.Addr
+	tlsState   *tls.ConnectionState
+
 	//application messages are queued up for send here
 	toSend [][]byte
 
@@ -31,12 +37,22 @@ type session struct {
 
 	sessionEvent chan internal.Event
 	messageEvent chan bool
+	clock        internal.Clock
 	application  Application
+	//optional, used by acceptors to validate logon requests
+	authenticator LogonAuthenticator
+	//optional, used by initiators to populate logon credentials
+	credentials CredentialsProvider
 	validator
 	stateMachine
-	stateTimer *internal.EventTimer
-	peerTimer  *internal.EventTimer
-	sentReset  bool
+	stateTimer  *internal.EventTimer
+	peerTimer   *internal.EventTimer
+	resendTimer *internal.EventTimer
+	sentReset   bool
+
+	//TestReqIDs of test requests sent since the peer last timed out, and a counter making them unique
+	pendingTestReqIDs []string
+	testReqCounter    int
 
 	targetDefaultApplVerID string
 
@@ -45,7 +61,6 @@ type session struct {
 	transportDataDictionary *datadictionary.DataDictionary
 	appDataDictionary       *datadictionary.DataDictionary
 
-	messagePool
 	timestampPrecision TimestampPrecision
 }
 
@@ -62,14 +77,18 @@ func (s *session) TargetDefaultApplicationVersionID() string {
 type connect struct {
 	messageOut chan<- []byte
 	messageIn  <-chan fixIn
+	remoteAddr net.Addr
+	tlsState   *tls.ConnectionState
 	err        chan<- error
 }
 
-func (s *session) connect(msgIn <-chan fixIn, msgOut chan<- []byte) error {
+func (s *session) connect(msgIn <-chan fixIn, msgOut chan<- []byte, netConn net.Conn) error {
 	rep := make(chan error)
 	s.admin <- connect{
 		messageOut: msgOut,
 		messageIn:  msgIn,
+		remoteAddr: netConn.RemoteAddr(),
+		tlsState:   connectionState(netConn),
 		err:        rep,
 	}
 
@@ -95,7 +114,7 @@ func (s *session) waitForInSessionTime() {
 }
 
 func (s *session) insertSendingTime(msg *Message) {
-	sendingTime := time.Now().UTC()
+	sendingTime := s.clock.Now().UTC()
 
 	if s.sessionID.BeginString >= BeginStringFIX42 {
 		msg.Header.SetField(tagSendingTime, FIXUTCTimestamp{Time: sendingTime, Precision: s.timestampPrecision})
@@ -145,10 +164,10 @@ func (s *session) shouldSendReset() bool {
 }
 
 func (s *session) sendLogon() error {
-	return s.sendLogonInReplyTo(s.shouldSendReset(), nil)
+	return s.sendLogonInReplyTo(s.shouldSendReset(), s.store.NextTargetMsgSeqNum(), nil)
 }
 
-func (s *session) sendLogonInReplyTo(setResetSeqNum bool, inReplyTo *Message) error {
+func (s *session) sendLogonInReplyTo(setResetSeqNum bool, nextExpectedMsgSeqNum int, inReplyTo *Message) error {
 	logon := NewMessage()
 	logon.Header.SetField(tagMsgType, FIXString("A"))
 	logon.Header.SetField(tagBeginString, FIXString(s.sessionID.BeginString))
@@ -165,6 +184,16 @@ func (s *session) sendLogonInReplyTo(setResetSeqNum bool, inReplyTo *Message) er
 		logon.Body.SetField(tagDefaultApplVerID, FIXString(s.DefaultApplVerID))
 	}
 
+	if s.EnableNextExpectedMsgSeqNum {
+		logon.Body.SetField(tagNextExpectedMsgSeqNum, FIXInt(nextExpectedMsgSeqNum))
+	}
+
+	if s.InitiateLogon {
+		if err := s.setCredentials(logon); err != nil {
+			return err
+		}
+	}
+
 	if err := s.dropAndSendInReplyTo(logon, inReplyTo); err != nil {
 		return err
 	}
@@ -316,7 +345,7 @@ func (s *session) prepMessageForSend(msg *Message, inReplyTo *Message) (msgBytes
 		}
 	}
 
-	msgBytes = msg.build()
+	msgBytes = msg.buildTo(getOutboundBuffer())
 	err = s.persist(seqNum, msgBytes)
 
 	return
@@ -353,7 +382,7 @@ func (s *session) sendBytes(msg []byte) {
 	copy(s.logMsgBuffer, msg)
 	s.messageOut <- msg
 	s.log.OnOutgoing(s.logMsgBuffer[:len(msg)])
-	s.stateTimer.Reset(s.HeartBtInt)
+	s.resetHeartbeatTimer()
 }
 
 func (s *session) doTargetTooHigh(reject targetTooHigh) (nextState resendState, err error) {
@@ -378,11 +407,7 @@ func (s *session) sendResendRequest(beginSeq, endSeq int) (nextState resendState
 	if endSeqNo < endSeq {
 		nextState.currentResendRangeEnd = endSeqNo
 	} else {
-		if s.sessionID.BeginString < BeginStringFIX42 {
-			endSeqNo = 999999
-		} else {
-			endSeqNo = 0
-		}
+		endSeqNo = s.resendRequestEndSeqNo(endSeq)
 	}
 	resend.Body.SetField(tagEndSeqNo, FIXInt(endSeqNo))
 
@@ -390,10 +415,29 @@ func (s *session) sendResendRequest(beginSeq, endSeq int) (nextState resendState
 		return
 	}
 	s.log.OnEventf("Sent ResendRequest FROM: %v TO: %v", beginSeq, endSeqNo)
+	s.resetResendTimer()
 
 	return
 }
 
+//resendRequestEndSeqNo returns the EndSeqNo of a ResendRequest for all messages up to endSeq, in the configured style
+func (s *session) resendRequestEndSeqNo(endSeq int) int {
+	switch s.ResendRequestEndSeqNoStyle {
+	case internal.EndSeqNoZero:
+		return 0
+	case internal.EndSeqNoInfinity:
+		return 999999
+	case internal.EndSeqNoExplicit:
+		return endSeq
+	}
+
+	if s.sessionID.BeginString < BeginStringFIX42 {
+		return 999999
+	}
+
+	return 0
+}
+
 func (s *session) handleLogon(msg *Message) error {
 	//Grab default app ver id from fixt.1.1 logon
 	if s.sessionID.BeginString == BeginStringFIXT11 {
@@ -411,6 +455,14 @@ func (s *session) handleLogon(msg *Message) error {
 		s.log.OnEvent("Received logon response")
 	} else {
 		s.log.OnEvent("Received logon request")
+		if err := s.authenticateLogon(msg); err != nil {
+			return err
+		}
+
+		if err := s.checkLogonHeartBtInt(msg); err != nil {
+			return err
+		}
+
 		resetStore = s.ResetOnLogon
 
 		if s.RefreshOnLogon {
@@ -440,22 +492,34 @@ func (s *session) handleLogon(msg *Message) error {
 		return err
 	}
 
+	nextSenderMsgSeqNum := s.store.NextSenderMsgSeqNum()
+	if err := s.checkNextExpectedMsgSeqNum(msg, nextSenderMsgSeqNum); err != nil {
+		return err
+	}
+
 	if !s.InitiateLogon {
-		var heartBtInt FIXInt
-		if err := msg.Body.GetField(tagHeartBtInt, &heartBtInt); err == nil {
-			s.HeartBtInt = time.Duration(heartBtInt) * time.Second
-		}
+		s.adoptLogonHeartBtInt(msg)
 
 		s.log.OnEvent("Responding to logon request")
-		if err := s.sendLogonInReplyTo(resetSeqNumFlag.Bool(), msg); err != nil {
+
+		nextExpectedMsgSeqNum := s.store.NextTargetMsgSeqNum()
+		if s.checkTargetTooHigh(msg) == nil {
+			nextExpectedMsgSeqNum++
+		}
+
+		if err := s.sendLogonInReplyTo(resetSeqNumFlag.Bool(), nextExpectedMsgSeqNum, msg); err != nil {
 			return err
 		}
 	}
 	s.sentReset = false
 
-	s.peerTimer.Reset(time.Duration(float64(1.2) * float64(s.HeartBtInt)))
+	s.resetPeerTimer(s.testRequestDelay())
 	s.application.OnLogon(s.sessionID)
 
+	if err := s.resendToNextExpectedMsgSeqNum(msg, nextSenderMsgSeqNum); err != nil {
+		return err
+	}
+
 	if err := s.checkTargetTooHigh(msg); err != nil {
 		return err
 	}
@@ -463,6 +527,50 @@ func (s *session) handleLogon(msg *Message) error {
 	return s.store.IncrNextTargetMsgSeqNum()
 }
 
+//checkNextExpectedMsgSeqNum rejects a logon with a NextExpectedMsgSeqNum for messages that were never sent
+func (s *session) checkNextExpectedMsgSeqNum(logon *Message, nextSenderMsgSeqNum int) error {
+	if !s.recoversWithNextExpectedMsgSeqNum(logon) {
+		return nil
+	}
+
+	nextExpectedMsgSeqNum, err := logon.Body.GetInt(tagNextExpectedMsgSeqNum)
+	if err != nil {
+		return err
+	}
+
+	if nextExpectedMsgSeqNum > nextSenderMsgSeqNum {
+		return RejectLogon{fmt.Sprintf("NextExpectedMsgSeqNum too high, expecting %v but received %v", nextSenderMsgSeqNum, nextExpectedMsgSeqNum)}
+	}
+
+	return nil
+}
+
+//resendToNextExpectedMsgSeqNum resends or gap fills the messages the counterparty has not received, from the
+//NextExpectedMsgSeqNum of its logon
+func (s *session) resendToNextExpectedMsgSeqNum(logon *Message, nextSenderMsgSeqNum int) error {
+	if !s.recoversWithNextExpectedMsgSeqNum(logon) {
+		return nil
+	}
+
+	nextExpectedMsgSeqNum, err := logon.Body.GetInt(tagNextExpectedMsgSeqNum)
+	if err != nil {
+		return err
+	}
+
+	if nextExpectedMsgSeqNum >= nextSenderMsgSeqNum {
+		return nil
+	}
+
+	s.log.OnEventf("Logon NextExpectedMsgSeqNum %v, resending messages FROM: %v TO: %v", nextExpectedMsgSeqNum, nextExpectedMsgSeqNum, nextSenderMsgSeqNum-1)
+	return inSession{}.resendMessages(s, nextExpectedMsgSeqNum, nextSenderMsgSeqNum-1, *logon)
+}
+
+//recoversWithNextExpectedMsgSeqNum is true if both sides of the session recover sequence gaps with the
+//NextExpectedMsgSeqNum of the logon, and no ResendRequest is needed
+func (s *session) recoversWithNextExpectedMsgSeqNum(logon *Message) bool {
+	return s.EnableNextExpectedMsgSeqNum && logon.Body.Has(tagNextExpectedMsgSeqNum)
+}
+
 func (s *session) initiateLogout(reason string) (err error) {
 	return s.initiateLogoutInReplyTo(reason, nil)
 }
@@ -473,7 +581,7 @@ func (s *session) initiateLogoutInReplyTo(reason string, inReplyTo *Message) (er
 		return
 	}
 	s.log.OnEvent("Inititated logout request")
-	time.AfterFunc(s.LogoutTimeout, func() { s.sessionEvent <- internal.LogoutTimeout })
+	s.clock.AfterFunc(s.LogoutTimeout, func() { s.sessionEvent <- internal.LogoutTimeout })
 	return
 }
 
@@ -514,6 +622,10 @@ func (s *session) verifySelect(msg *Message, checkTooHigh bool, checkTooLow bool
 		}
 	}
 
+	if reject := s.checkPossDup(msg); reject != nil {
+		return reject
+	}
+
 	if s.validator != nil {
 		if reject := s.validator.Validate(msg); reject != nil {
 			return reject
@@ -533,9 +645,57 @@ func (s *session) fromCallback(msg *Message) MessageRejectError {
 		return s.application.FromAdmin(msg, s.sessionID)
 	}
 
+	if app, ok := s.application.(PossResendApplication); ok {
+		var possResend FIXBoolean
+		if msg.Header.Has(tagPossResend) {
+			if err := msg.Header.GetField(tagPossResend, &possResend); err != nil && !s.LenientCompliance {
+				return err
+			}
+		}
+
+		return app.FromAppPossResend(msg, s.sessionID, possResend.Bool())
+	}
+
 	return s.application.FromApp(msg, s.sessionID)
 }
 
+//checkPossDup requires a possible duplicate to carry an OrigSendingTime no later than its SendingTime. Only checked
+//with strict compliance, possible duplicates with a MsgSeqNum lower than expected are always checked.
+func (s *session) checkPossDup(msg *Message) MessageRejectError {
+	if !s.StrictCompliance || !msg.Header.Has(tagPossDupFlag) {
+		return nil
+	}
+
+	var possDupFlag FIXBoolean
+	if err := msg.Header.GetField(tagPossDupFlag, &possDupFlag); err != nil {
+		return err
+	}
+
+	if !possDupFlag.Bool() {
+		return nil
+	}
+
+	if !msg.Header.Has(tagOrigSendingTime) {
+		return RequiredTagMissing(tagOrigSendingTime)
+	}
+
+	origSendingTime, err := msg.Header.GetTime(tagOrigSendingTime)
+	if err != nil {
+		return err
+	}
+
+	sendingTime, err := msg.Header.GetTime(tagSendingTime)
+	if err != nil {
+		return err
+	}
+
+	if sendingTime.Before(origSendingTime) {
+		return sendingTimeAccuracyProblem()
+	}
+
+	return nil
+}
+
 func (s *session) checkTargetTooLow(msg *Message) MessageRejectError {
 	if !msg.Header.Has(tagMsgSeqNum) {
 		return RequiredTagMissing(tagMsgSeqNum)
@@ -604,7 +764,7 @@ func (s *session) checkSendingTime(msg *Message) MessageRejectError {
 		return err
 	}
 
-	if delta := time.Since(sendingTime); delta <= -1*s.MaxLatency || delta >= s.MaxLatency {
+	if delta := s.clock.Now().Sub(sendingTime); delta <= -1*s.MaxLatency || delta >= s.MaxLatency {
 		return sendingTimeAccuracyProblem()
 	}
 
@@ -673,14 +833,6 @@ type fixIn struct {
 	receiveTime time.Time
 }
 
-func (s *session) returnToPool(msg *Message) {
-	s.messagePool.Put(msg)
-	if msg.rawMessage != nil {
-		bufferPool.Put(msg.rawMessage)
-		msg.rawMessage = nil
-	}
-}
-
 func (s *session) onDisconnect() {
 	s.log.OnEvent("Disconnected")
 	if s.ResetOnDisconnect {
@@ -695,6 +847,8 @@ func (s *session) onDisconnect() {
 	}
 
 	s.messageIn = nil
+	s.remoteAddr = nil
+	s.tlsState = nil
 }
 
 func (s *session) onAdmin(msg interface{}) {
@@ -716,6 +870,8 @@ func (s *session) onAdmin(msg interface{}) {
 
 		s.messageIn = msg.messageIn
 		s.messageOut = msg.messageOut
+		s.remoteAddr = msg.remoteAddr
+		s.tlsState = msg.tlsState
 		s.sentReset = false
 
 		s.Connect(s)
@@ -731,16 +887,32 @@ func (s *session) onAdmin(msg interface{}) {
 	}
 }
 
+//newEventTimer returns a timer delivering event to the session loop. Once stopped, a pending delivery is abandoned as
+//the loop no longer receives.
+func (s *session) newEventTimer(event internal.Event) *internal.EventTimer {
+	var t *internal.EventTimer
+	t = internal.NewEventTimer(s.clock, func() {
+		select {
+		case s.sessionEvent <- event:
+		case <-t.Done():
+		}
+	})
+
+	return t
+}
+
 func (s *session) run() {
 	s.Start(s)
 
-	s.stateTimer = internal.NewEventTimer(func() { s.sessionEvent <- internal.NeedHeartbeat })
-	s.peerTimer = internal.NewEventTimer(func() { s.sessionEvent <- internal.PeerTimeout })
-	ticker := time.NewTicker(time.Second)
+	s.stateTimer = s.newEventTimer(internal.NeedHeartbeat)
+	s.peerTimer = s.newEventTimer(internal.PeerTimeout)
+	s.resendTimer = s.newEventTimer(internal.ResendRequestTimeout)
+	ticker := s.clock.NewTicker(time.Second)
 
 	defer func() {
 		s.stateTimer.Stop()
 		s.peerTimer.Stop()
+		s.resendTimer.Stop()
 		ticker.Stop()
 	}()
 
@@ -763,7 +935,7 @@ func (s *session) run() {
 		case evt := <-s.sessionEvent:
 			s.Timeout(s, evt)
 
-		case now := <-ticker.C:
+		case now := <-ticker.C():
 			s.CheckSessionTime(s, now)
 		}
 	}
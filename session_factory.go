@@ -2,8 +2,11 @@ package quickfix
 
 import (
 	"errors"
+	"fmt"
 	"net"
+	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/quickfixgo/quickfix/config"
@@ -40,9 +43,17 @@ var applVerIDLookup = map[string]string{
 	"FIX.5.0SP2":     "9",
 }
 
+const (
+	defaultTestRequestDelayMultiplier = 1.2
+	defaultHeartbeatTimeoutMultiplier = 1.2
+)
+
 type sessionFactory struct {
 	//True if building sessions that initiate logon
 	BuildInitiators bool
+
+	//optional, defaults to the wall clock
+	clock Clock
 }
 
 //Creates Session, associates with internal session registry
@@ -67,7 +78,7 @@ func (f sessionFactory) createSession(
 func (f sessionFactory) newSession(
 	sessionID SessionID, storeFactory MessageStoreFactory, settings *SessionSettings, logFactory LogFactory,
 	application Application) (s *session, err error) {
-	s = &session{sessionID: sessionID}
+	s = &session{sessionID: sessionID, clock: f.sessionClock()}
 
 	var validatorSettings = defaultValidatorSettings
 	if settings.HasSetting(config.ValidateFieldsOutOfOrder) {
@@ -155,6 +166,12 @@ func (f sessionFactory) newSession(
 		}
 	}
 
+	if settings.HasSetting(config.EnableNextExpectedMsgSeqNum) {
+		if s.EnableNextExpectedMsgSeqNum, err = settings.BoolSetting(config.EnableNextExpectedMsgSeqNum); err != nil {
+			return
+		}
+	}
+
 	if settings.HasSetting(config.CheckLatency) {
 		var doCheckLatency bool
 		if doCheckLatency, err = settings.BoolSetting(config.CheckLatency); err != nil {
@@ -180,77 +197,109 @@ func (f sessionFactory) newSession(
 		s.MaxLatency = time.Duration(maxLatency) * time.Second
 	}
 
-	if settings.HasSetting(config.ResendRequestChunkSize) {
-		if s.ResendRequestChunkSize, err = settings.IntSetting(config.ResendRequestChunkSize); err != nil {
+	s.TestRequestDelayMultiplier = defaultTestRequestDelayMultiplier
+	if settings.HasSetting(config.TestRequestDelayMultiplier) {
+		if s.TestRequestDelayMultiplier, err = settings.FloatSetting(config.TestRequestDelayMultiplier); err != nil {
+			return
+		}
+
+		if s.TestRequestDelayMultiplier <= 0 {
+			err = errors.New("TestRequestDelayMultiplier must be greater than zero")
 			return
 		}
 	}
 
-	if settings.HasSetting(config.StartTime) || settings.HasSetting(config.EndTime) {
-		var startTimeStr, endTimeStr string
-		if startTimeStr, err = settings.Setting(config.StartTime); err != nil {
+	s.HeartbeatTimeoutMultiplier = defaultHeartbeatTimeoutMultiplier
+	if settings.HasSetting(config.HeartbeatTimeoutMultiplier) {
+		if s.HeartbeatTimeoutMultiplier, err = settings.FloatSetting(config.HeartbeatTimeoutMultiplier); err != nil {
 			return
 		}
 
-		if endTimeStr, err = settings.Setting(config.EndTime); err != nil {
+		if s.HeartbeatTimeoutMultiplier <= 0 {
+			err = errors.New("HeartbeatTimeoutMultiplier must be greater than zero")
 			return
 		}
+	}
 
-		var start, end internal.TimeOfDay
-		if start, err = internal.ParseTimeOfDay(startTimeStr); err != nil {
+	s.MaxTestRequestsBeforeDisconnect = 1
+	if settings.HasSetting(config.MaxTestRequestsBeforeDisconnect) {
+		if s.MaxTestRequestsBeforeDisconnect, err = settings.IntSetting(config.MaxTestRequestsBeforeDisconnect); err != nil {
 			return
 		}
 
-		if end, err = internal.ParseTimeOfDay(endTimeStr); err != nil {
+		if s.MaxTestRequestsBeforeDisconnect <= 0 {
+			err = errors.New("MaxTestRequestsBeforeDisconnect must be a positive integer")
 			return
 		}
+	}
 
-		loc := time.UTC
-		if settings.HasSetting(config.TimeZone) {
-			var locStr string
-			if locStr, err = settings.Setting(config.TimeZone); err != nil {
-				return
-			}
+	if settings.HasSetting(config.ResendRequestChunkSize) {
+		if s.ResendRequestChunkSize, err = settings.IntSetting(config.ResendRequestChunkSize); err != nil {
+			return
+		}
+	}
 
-			loc, err = time.LoadLocation(locStr)
-			if err != nil {
-				return
-			}
+	if settings.HasSetting(config.ResendRequestTimeout) {
+		var timeout int
+		if timeout, err = settings.IntSetting(config.ResendRequestTimeout); err != nil {
+			return
 		}
 
-		if !settings.HasSetting(config.StartDay) && !settings.HasSetting(config.EndDay) {
-			s.SessionTime = internal.NewTimeRangeInLocation(start, end, loc)
-		} else {
-			var startDayStr, endDayStr string
-			if startDayStr, err = settings.Setting(config.StartDay); err != nil {
-				return
-			}
+		if timeout < 0 {
+			err = errors.New("ResendRequestTimeout must not be negative")
+			return
+		}
 
-			if endDayStr, err = settings.Setting(config.EndDay); err != nil {
-				return
-			}
+		s.ResendRequestTimeout = time.Duration(timeout) * time.Second
+	}
 
-			parseDay := func(setting, dayStr string) (day time.Weekday, err error) {
-				day, ok := dayLookup[dayStr]
-				if !ok {
-					return day, IncorrectFormatForSetting{Setting: setting, Value: dayStr}
-				}
-				return
-			}
+	s.MaxResendRequestRetries = 3
+	if settings.HasSetting(config.MaxResendRequestRetries) {
+		if s.MaxResendRequestRetries, err = settings.IntSetting(config.MaxResendRequestRetries); err != nil {
+			return
+		}
 
-			var startDay, endDay time.Weekday
-			if startDay, err = parseDay(config.StartDay, startDayStr); err != nil {
-				return
-			}
+		if s.MaxResendRequestRetries < 0 {
+			err = errors.New("MaxResendRequestRetries must not be negative")
+			return
+		}
+	}
 
-			if endDay, err = parseDay(config.EndDay, endDayStr); err != nil {
-				return
-			}
+	if settings.HasSetting(config.MaxMessageStashSize) {
+		if s.MaxMessageStashSize, err = settings.IntSetting(config.MaxMessageStashSize); err != nil {
+			return
+		}
 
-			s.SessionTime = internal.NewWeekRangeInLocation(start, end, startDay, endDay, loc)
+		if s.MaxMessageStashSize <= 0 {
+			err = errors.New("MaxMessageStashSize must be greater than zero")
+			return
 		}
 	}
 
+	if settings.HasSetting(config.ResendRequestEndSeqNoStyle) {
+		var endSeqNoStyle string
+		if endSeqNoStyle, err = settings.Setting(config.ResendRequestEndSeqNoStyle); err != nil {
+			return
+		}
+
+		switch endSeqNoStyle {
+		case "zero":
+			s.ResendRequestEndSeqNoStyle = internal.EndSeqNoZero
+		case "infinity":
+			s.ResendRequestEndSeqNoStyle = internal.EndSeqNoInfinity
+		case "explicit":
+			s.ResendRequestEndSeqNoStyle = internal.EndSeqNoExplicit
+
+		default:
+			err = IncorrectFormatForSetting{Setting: config.ResendRequestEndSeqNoStyle, Value: endSeqNoStyle}
+			return
+		}
+	}
+
+	if err = f.buildSessionTimes(s, settings); err != nil {
+		return
+	}
+
 	if settings.HasSetting(config.TimeStampPrecision) {
 		var precisionStr string
 		if precisionStr, err = settings.Setting(config.TimeStampPrecision); err != nil {
@@ -266,6 +315,8 @@ func (f sessionFactory) newSession(
 			s.timestampPrecision = Micros
 		case "NANOS":
 			s.timestampPrecision = Nanos
+		case "PICOS":
+			s.timestampPrecision = Picos
 
 		default:
 			err = IncorrectFormatForSetting{Setting: config.TimeStampPrecision, Value: precisionStr}
@@ -273,6 +324,25 @@ func (f sessionFactory) newSession(
 		}
 	}
 
+	if settings.HasSetting(config.SessionComplianceLevel) {
+		var complianceLevel string
+		if complianceLevel, err = settings.Setting(config.SessionComplianceLevel); err != nil {
+			return
+		}
+
+		switch complianceLevel {
+		case "standard":
+		case "strict":
+			s.StrictCompliance = true
+		case "lenient":
+			s.LenientCompliance = true
+
+		default:
+			err = IncorrectFormatForSetting{Setting: config.SessionComplianceLevel, Value: complianceLevel}
+			return
+		}
+	}
+
 	if settings.HasSetting(config.PersistMessages) {
 		var persistMessages bool
 		if persistMessages, err = settings.BoolSetting(config.PersistMessages); err != nil {
@@ -286,6 +356,10 @@ func (f sessionFactory) newSession(
 		if err = f.buildInitiatorSettings(s, settings); err != nil {
 			return
 		}
+	} else {
+		if err = f.buildAcceptorSettings(s, settings); err != nil {
+			return
+		}
 	}
 
 	if s.log, err = logFactory.CreateSessionLog(s.sessionID); err != nil {
@@ -295,11 +369,21 @@ func (f sessionFactory) newSession(
 	if s.store, err = storeFactory.Create(s.sessionID); err != nil {
 		return
 	}
+	s.setClock(s.clock)
 
 	s.sessionEvent = make(chan internal.Event)
 	s.messageEvent = make(chan bool, 1)
 	s.admin = make(chan interface{})
 	s.application = application
+	if authenticator, ok := application.(LogonAuthenticator); ok {
+		s.authenticator = authenticator
+	}
+
+	if f.BuildInitiators {
+		if s.credentials, err = f.buildCredentialsProvider(settings, application); err != nil {
+			return
+		}
+	}
 	return
 }
 
@@ -364,6 +448,80 @@ func (f sessionFactory) buildInitiatorSettings(session *session, settings *Sessi
 	return f.configureSocketConnectAddress(session, settings)
 }
 
+func (f sessionFactory) buildAcceptorSettings(session *session, settings *SessionSettings) error {
+	limits, err := parseRemoteLimits(settings)
+	if err != nil {
+		return err
+	}
+	session.AllowedRemoteAddresses = limits.allowedRemoteAddresses
+	session.MaxConnectionsPerRemote = limits.maxConnectionsPerRemote
+
+	if settings.HasSetting(config.MinHeartBtInt) {
+		minHeartBtInt, err := settings.IntSetting(config.MinHeartBtInt)
+		if err != nil {
+			return err
+		}
+
+		if minHeartBtInt < 0 {
+			return errors.New("MinHeartBtInt must not be negative")
+		}
+
+		session.MinHeartBtInt = time.Duration(minHeartBtInt) * time.Second
+	}
+
+	if settings.HasSetting(config.MaxHeartBtInt) {
+		maxHeartBtInt, err := settings.IntSetting(config.MaxHeartBtInt)
+		if err != nil {
+			return err
+		}
+
+		if maxHeartBtInt <= 0 {
+			return errors.New("MaxHeartBtInt must be greater than zero")
+		}
+
+		session.MaxHeartBtInt = time.Duration(maxHeartBtInt) * time.Second
+		if session.MaxHeartBtInt < session.MinHeartBtInt {
+			return errors.New("MaxHeartBtInt must not be less than MinHeartBtInt")
+		}
+	}
+
+	return nil
+}
+
+func (f sessionFactory) buildCredentialsProvider(settings *SessionSettings, application Application) (CredentialsProvider, error) {
+	if provider, ok := application.(CredentialsProvider); ok {
+		return provider, nil
+	}
+
+	if settings.HasSetting(config.LogonCredentialsFile) {
+		path, err := settings.Setting(config.LogonCredentialsFile)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewFileCredentialsProvider(path), nil
+	}
+
+	if !(settings.HasSetting(config.LogonUsername) || settings.HasSetting(config.LogonPassword) || settings.HasSetting(config.LogonNewPassword)) {
+		return nil, nil
+	}
+
+	var credentials Credentials
+	if settings.HasSetting(config.LogonUsername) {
+		credentials.Username, _ = settings.Setting(config.LogonUsername)
+	}
+
+	if settings.HasSetting(config.LogonPassword) {
+		credentials.Password, _ = settings.Setting(config.LogonPassword)
+	}
+
+	if settings.HasSetting(config.LogonNewPassword) {
+		credentials.NewPassword, _ = settings.Setting(config.LogonNewPassword)
+	}
+
+	return NewStaticCredentialsProvider(credentials), nil
+}
+
 func (f sessionFactory) configureSocketConnectAddress(session *session, settings *SessionSettings) (err error) {
 	session.SocketConnectAddress = []string{}
 
@@ -394,3 +552,183 @@ func (f sessionFactory) configureSocketConnectAddress(session *session, settings
 		i++
 	}
 }
+
+//buildSessionTimes configures the session reset window from StartTime and EndTime, and the logon window from LogonTime
+//and LogoutTime.
+func (f sessionFactory) buildSessionTimes(session *session, settings *SessionSettings) (err error) {
+	loc := time.UTC
+	if settings.HasSetting(config.TimeZone) {
+		var locStr string
+		if locStr, err = settings.Setting(config.TimeZone); err != nil {
+			return
+		}
+
+		if loc, err = time.LoadLocation(locStr); err != nil {
+			return
+		}
+	}
+
+	var calendar *internal.Calendar
+	if settings.HasSetting(config.SessionCalendarFile) {
+		if calendar, err = loadSessionCalendar(settings); err != nil {
+			return
+		}
+	}
+
+	if hasTimeRange(settings, config.StartTime, config.EndTime) {
+		if session.SessionTime, err = parseTimeRange(settings, config.StartTime, config.EndTime, config.StartDay, config.EndDay, loc); err != nil {
+			return
+		}
+		session.SessionTime.WithCalendar(calendar)
+	}
+
+	if hasTimeRange(settings, config.LogonTime, config.LogoutTime) {
+		if session.LogonTime, err = parseTimeRange(settings, config.LogonTime, config.LogoutTime, config.LogonDay, config.LogoutDay, loc); err != nil {
+			return
+		}
+		session.LogonTime.WithCalendar(calendar)
+	}
+
+	if calendar != nil && session.SessionTime == nil && session.LogonTime == nil {
+		err = errors.New("SessionCalendarFile requires StartTime and EndTime, or LogonTime and LogoutTime")
+	}
+
+	return
+}
+
+//hasTimeRange returns true if the start and end time settings, or any day of week overrides of them, are configured.
+func hasTimeRange(settings *SessionSettings, startTimeSetting, endTimeSetting string) bool {
+	if settings.HasSetting(startTimeSetting) || settings.HasSetting(endTimeSetting) {
+		return true
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if settings.HasSetting(day.String()+startTimeSetting) || settings.HasSetting(day.String()+endTimeSetting) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//parseTimeRange parses the daily time range configured by the start and end time settings, or the week long time range if
+//the start and end day settings are also configured. Comma separated start and end times, or day of week overrides such
+//as MondayStartTime, configure a schedule of several windows a day.
+func parseTimeRange(settings *SessionSettings, startTimeSetting, endTimeSetting, startDaySetting, endDaySetting string, loc *time.Location) (*internal.TimeRange, error) {
+	var windows []internal.TimeWindow
+	if settings.HasSetting(startTimeSetting) || settings.HasSetting(endTimeSetting) {
+		var err error
+		if windows, err = parseTimeWindows(settings, startTimeSetting, endTimeSetting); err != nil {
+			return nil, err
+		}
+	}
+
+	var dayWindows map[time.Weekday][]internal.TimeWindow
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		dayStartTimeSetting, dayEndTimeSetting := day.String()+startTimeSetting, day.String()+endTimeSetting
+		if !settings.HasSetting(dayStartTimeSetting) && !settings.HasSetting(dayEndTimeSetting) {
+			continue
+		}
+
+		w, err := parseTimeWindows(settings, dayStartTimeSetting, dayEndTimeSetting)
+		if err != nil {
+			return nil, err
+		}
+
+		if dayWindows == nil {
+			dayWindows = make(map[time.Weekday][]internal.TimeWindow)
+		}
+		dayWindows[day] = w
+	}
+
+	hasDays := settings.HasSetting(startDaySetting) || settings.HasSetting(endDaySetting)
+	if len(windows) != 1 || dayWindows != nil {
+		if hasDays {
+			return nil, fmt.Errorf("%v and %v cannot be used with multiple session windows", startDaySetting, endDaySetting)
+		}
+
+		return internal.NewScheduleInLocation(windows, dayWindows, loc)
+	}
+
+	start, end := windows[0].Start, windows[0].End
+	if !hasDays {
+		return internal.NewTimeRangeInLocation(start, end, loc), nil
+	}
+
+	parseDay := func(setting string) (day time.Weekday, err error) {
+		dayStr, err := settings.Setting(setting)
+		if err != nil {
+			return
+		}
+
+		day, ok := dayLookup[dayStr]
+		if !ok {
+			return day, IncorrectFormatForSetting{Setting: setting, Value: dayStr}
+		}
+		return
+	}
+
+	startDay, err := parseDay(startDaySetting)
+	if err != nil {
+		return nil, err
+	}
+
+	endDay, err := parseDay(endDaySetting)
+	if err != nil {
+		return nil, err
+	}
+
+	return internal.NewWeekRangeInLocation(start, end, startDay, endDay, loc), nil
+}
+
+//parseTimeWindows parses comma separated lists of start and end times into daily windows.
+func parseTimeWindows(settings *SessionSettings, startTimeSetting, endTimeSetting string) ([]internal.TimeWindow, error) {
+	startTimeStr, err := settings.Setting(startTimeSetting)
+	if err != nil {
+		return nil, err
+	}
+
+	endTimeStr, err := settings.Setting(endTimeSetting)
+	if err != nil {
+		return nil, err
+	}
+
+	startTimes, endTimes := strings.Split(startTimeStr, ","), strings.Split(endTimeStr, ",")
+	if len(startTimes) != len(endTimes) {
+		return nil, fmt.Errorf("%v and %v must have the same number of times", startTimeSetting, endTimeSetting)
+	}
+
+	windows := make([]internal.TimeWindow, len(startTimes))
+	for i := range startTimes {
+		if windows[i].Start, err = internal.ParseTimeOfDay(strings.TrimSpace(startTimes[i])); err != nil {
+			return nil, err
+		}
+
+		if windows[i].End, err = internal.ParseTimeOfDay(strings.TrimSpace(endTimes[i])); err != nil {
+			return nil, err
+		}
+	}
+
+	return windows, nil
+}
+
+//loadSessionCalendar reads the non-session dates from the SessionCalendarFile.
+func loadSessionCalendar(settings *SessionSettings) (*internal.Calendar, error) {
+	calendarFile, err := settings.Setting(config.SessionCalendarFile)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(calendarFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	calendar, err := internal.ParseCalendar(file)
+	if err != nil {
+		return nil, IncorrectFormatForSetting{Setting: config.SessionCalendarFile, Value: calendarFile, Err: err}
+	}
+
+	return calendar, nil
+}
@@ -1,6 +1,9 @@
 package quickfix
 
 import (
+	"io/ioutil"
+	"os"
+	"path"
 	"testing"
 	"time"
 
@@ -47,6 +50,7 @@ func (s *SessionFactorySuite) TestDefaults() {
 	s.False(session.InitiateLogon)
 	s.Equal(0, session.ResendRequestChunkSize)
 	s.False(session.EnableLastMsgSeqNumProcessed)
+	s.False(session.EnableNextExpectedMsgSeqNum)
 	s.False(session.SkipCheckLatency)
 	s.Equal(Millis, session.timestampPrecision)
 	s.Equal(120*time.Second, session.MaxLatency)
@@ -150,6 +154,23 @@ func (s *SessionFactorySuite) TestEnableLastMsgSeqNumProcessed() {
 	}
 }
 
+func (s *SessionFactorySuite) TestEnableNextExpectedMsgSeqNum() {
+	var tests = []struct {
+		setting  string
+		expected bool
+	}{{"Y", true}, {"N", false}}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.SessionSettings.Set(config.EnableNextExpectedMsgSeqNum, test.setting)
+		session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+		s.Nil(err)
+		s.NotNil(session)
+
+		s.Equal(test.expected, session.EnableNextExpectedMsgSeqNum)
+	}
+}
+
 func (s *SessionFactorySuite) TestCheckLatency() {
 	var tests = []struct {
 		setting  string
@@ -195,6 +216,148 @@ func (s *SessionFactorySuite) TestStartAndEndTimeAndTimeZone() {
 	)
 }
 
+func (s *SessionFactorySuite) TestHeartbeatSupervision() {
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Require().Nil(err)
+	s.Equal(1.2, session.TestRequestDelayMultiplier)
+	s.Equal(1.2, session.HeartbeatTimeoutMultiplier)
+	s.Equal(1, session.MaxTestRequestsBeforeDisconnect)
+
+	s.SessionSettings.Set(config.TestRequestDelayMultiplier, "2")
+	s.SessionSettings.Set(config.HeartbeatTimeoutMultiplier, "0.5")
+	s.SessionSettings.Set(config.MaxTestRequestsBeforeDisconnect, "3")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Require().Nil(err)
+	s.Equal(2.0, session.TestRequestDelayMultiplier)
+	s.Equal(0.5, session.HeartbeatTimeoutMultiplier)
+	s.Equal(3, session.MaxTestRequestsBeforeDisconnect)
+
+	session.HeartBtInt = 30 * time.Second
+	s.Equal(60*time.Second, session.testRequestDelay())
+	s.Equal(15*time.Second, session.heartbeatTimeout())
+
+	var tests = []struct {
+		setting, value string
+	}{
+		{config.TestRequestDelayMultiplier, "0"},
+		{config.HeartbeatTimeoutMultiplier, "-1"},
+		{config.MaxTestRequestsBeforeDisconnect, "0"},
+		{config.MaxTestRequestsBeforeDisconnect, "1.5"},
+	}
+
+	for _, test := range tests {
+		s.SetupTest()
+		s.SessionSettings.Set(test.setting, test.value)
+		_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+		s.NotNil(err, "%v=%v", test.setting, test.value)
+	}
+}
+
+func (s *SessionFactorySuite) TestSessionCalendarFile() {
+	dir, err := ioutil.TempDir("", "calendar")
+	s.Require().Nil(err)
+	defer os.RemoveAll(dir)
+
+	calendarFile := path.Join(dir, "holidays.txt")
+	s.Require().Nil(ioutil.WriteFile(calendarFile, []byte("# exchange holidays\n2024-12-25\n"), 0600))
+
+	s.SessionSettings.Set(config.SessionCalendarFile, calendarFile)
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "SessionCalendarFile requires StartTime and EndTime")
+
+	s.SessionSettings.Set(config.StartTime, "12:00:00")
+	s.SessionSettings.Set(config.EndTime, "14:00:00")
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Require().Nil(err)
+	s.True(session.SessionTime.IsInRange(time.Date(2024, time.December, 24, 13, 0, 0, 0, time.UTC)))
+	s.False(session.SessionTime.IsInRange(time.Date(2024, time.December, 25, 13, 0, 0, 0, time.UTC)))
+
+	s.Require().Nil(ioutil.WriteFile(calendarFile, []byte("Christmas\n"), 0600))
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err)
+
+	s.SessionSettings.Set(config.SessionCalendarFile, path.Join(dir, "missing.txt"))
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err)
+}
+
+func (s *SessionFactorySuite) TestLogonAndLogoutTime() {
+	s.SessionSettings.Set(config.StartTime, "00:00:00")
+	s.SessionSettings.Set(config.EndTime, "00:00:00")
+	s.SessionSettings.Set(config.StartDay, "Sun")
+	s.SessionSettings.Set(config.EndDay, "Sat")
+	s.SessionSettings.Set(config.LogonTime, "08:00:00")
+	s.SessionSettings.Set(config.LogoutTime, "17:00:00")
+	s.SessionSettings.Set(config.TimeZone, "Local")
+
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(
+		*internal.NewWeekRangeInLocation(internal.NewTimeOfDay(0, 0, 0), internal.NewTimeOfDay(0, 0, 0), time.Sunday, time.Saturday, time.Local),
+		*session.SessionTime,
+	)
+	s.Equal(
+		*internal.NewTimeRangeInLocation(internal.NewTimeOfDay(8, 0, 0), internal.NewTimeOfDay(17, 0, 0), time.Local),
+		*session.LogonTime,
+	)
+
+	s.SessionSettings.Set(config.LogonDay, "Mon")
+	s.SessionSettings.Set(config.LogoutDay, "Fri")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(
+		*internal.NewWeekRangeInLocation(internal.NewTimeOfDay(8, 0, 0), internal.NewTimeOfDay(17, 0, 0), time.Monday, time.Friday, time.Local),
+		*session.LogonTime,
+	)
+
+	s.SessionSettings.Set(config.LogoutDay, "Someday")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err)
+}
+
+func (s *SessionFactorySuite) TestMultipleSessionWindows() {
+	s.SessionSettings.Set(config.StartTime, "07:00:00, 13:30:00")
+	s.SessionSettings.Set(config.EndTime, "12:00:00,20:00:00")
+	s.SessionSettings.Set("FridayStartTime", "07:00:00")
+	s.SessionSettings.Set("FridayEndTime", "12:00:00")
+
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Require().Nil(err)
+
+	expected, err := internal.NewScheduleInLocation(
+		[]internal.TimeWindow{
+			{Start: internal.NewTimeOfDay(7, 0, 0), End: internal.NewTimeOfDay(12, 0, 0)},
+			{Start: internal.NewTimeOfDay(13, 30, 0), End: internal.NewTimeOfDay(20, 0, 0)},
+		},
+		map[time.Weekday][]internal.TimeWindow{
+			time.Friday: {{Start: internal.NewTimeOfDay(7, 0, 0), End: internal.NewTimeOfDay(12, 0, 0)}},
+		},
+		time.UTC,
+	)
+	s.Require().Nil(err)
+	s.Equal(*expected, *session.SessionTime)
+
+	s.SessionSettings.Set(config.StartDay, "Mon")
+	s.SessionSettings.Set(config.EndDay, "Fri")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "StartDay and EndDay cannot be used with multiple windows")
+
+	s.SetupTest()
+	s.SessionSettings.Set(config.StartTime, "07:00:00,13:30:00")
+	s.SessionSettings.Set(config.EndTime, "12:00:00")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "StartTime and EndTime must have the same number of times")
+
+	s.SetupTest()
+	s.SessionSettings.Set("MondayLogonTime", "08:00:00")
+	s.SessionSettings.Set("MondayLogoutTime", "17:00:00")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Require().Nil(err)
+	s.Nil(session.SessionTime)
+	s.True(session.LogonTime.IsInRange(time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC)))
+	s.False(session.LogonTime.IsInRange(time.Date(2024, time.December, 17, 9, 0, 0, 0, time.UTC)))
+}
+
 func (s *SessionFactorySuite) TestStartAndEndTimeAndStartAndEndDay() {
 	var tests = []struct {
 		startDay, endDay string
@@ -358,6 +521,81 @@ func (s *SessionFactorySuite) TestNewSessionBuildInitiators() {
 	s.Equal("127.0.0.1:5000", session.SocketConnectAddress[0])
 }
 
+func (s *SessionFactorySuite) TestNewSessionBuildInitiatorsCredentials() {
+	s.sessionFactory.BuildInitiators = true
+	s.SessionSettings.Set(config.HeartBtInt, "34")
+	s.SessionSettings.Set(config.SocketConnectHost, "127.0.0.1")
+	s.SessionSettings.Set(config.SocketConnectPort, "5000")
+
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Nil(session.credentials, "no credentials by default")
+
+	s.SessionSettings.Set(config.LogonUsername, "user")
+	s.SessionSettings.Set(config.LogonPassword, "pass")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Require().NotNil(session.credentials)
+	credentials, err := session.credentials.Credentials(s.SessionID)
+	s.Nil(err)
+	s.Equal(Credentials{Username: "user", Password: "pass"}, credentials)
+
+	s.SessionSettings.Set(config.LogonCredentialsFile, "credentials.cfg")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(NewFileCredentialsProvider("credentials.cfg"), session.credentials)
+}
+
+func (s *SessionFactorySuite) TestNewSessionAcceptorConnectionLimits() {
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Empty(session.AllowedRemoteAddresses)
+	s.Equal(0, session.MaxConnectionsPerRemote)
+
+	s.SessionSettings.Set(config.AllowedRemoteAddresses, "10.1.0.0/16,192.168.5.20")
+	s.SessionSettings.Set(config.MaxConnectionsPerRemote, "2")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Len(session.AllowedRemoteAddresses, 2)
+	s.Equal(2, session.MaxConnectionsPerRemote)
+
+	s.SessionSettings.Set(config.MaxConnectionsPerRemote, "0")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "MaxConnectionsPerRemote must be greater than zero")
+
+	s.SessionSettings.Set(config.MaxConnectionsPerRemote, "2")
+	s.SessionSettings.Set(config.AllowedRemoteAddresses, "10.1.0.0/99")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "AllowedRemoteAddresses must be valid CIDR blocks")
+}
+
+func (s *SessionFactorySuite) TestNewSessionAcceptorHeartBtIntBounds() {
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(time.Duration(0), session.MinHeartBtInt)
+	s.Equal(time.Duration(0), session.MaxHeartBtInt)
+
+	s.SessionSettings.Set(config.MinHeartBtInt, "10")
+	s.SessionSettings.Set(config.MaxHeartBtInt, "60")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(10*time.Second, session.MinHeartBtInt)
+	s.Equal(60*time.Second, session.MaxHeartBtInt)
+
+	s.SessionSettings.Set(config.MinHeartBtInt, "-1")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "MinHeartBtInt must not be negative")
+
+	s.SessionSettings.Set(config.MinHeartBtInt, "10")
+	s.SessionSettings.Set(config.MaxHeartBtInt, "0")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "MaxHeartBtInt must be greater than zero")
+
+	s.SessionSettings.Set(config.MaxHeartBtInt, "5")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "MaxHeartBtInt must not be less than MinHeartBtInt")
+}
+
 func (s *SessionFactorySuite) TestNewSessionBuildInitiatorsValidHeartBtInt() {
 	s.sessionFactory.BuildInitiators = true
 
@@ -529,6 +767,7 @@ func (s *SessionFactorySuite) TestNewSessionTimestampPrecision() {
 		{"MILLIS", Millis},
 		{"MICROS", Micros},
 		{"NANOS", Nanos},
+		{"PICOS", Picos},
 	}
 
 	for _, test := range tests {
@@ -540,6 +779,94 @@ func (s *SessionFactorySuite) TestNewSessionTimestampPrecision() {
 	}
 }
 
+func (s *SessionFactorySuite) TestNewSessionResendRequestEndSeqNoStyle() {
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(internal.EndSeqNoDefault, session.ResendRequestEndSeqNoStyle)
+
+	s.SessionSettings.Set(config.ResendRequestEndSeqNoStyle, "blah")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err)
+
+	var tests = []struct {
+		config string
+		style  internal.ResendRequestEndSeqNoStyle
+	}{
+		{"zero", internal.EndSeqNoZero},
+		{"infinity", internal.EndSeqNoInfinity},
+		{"explicit", internal.EndSeqNoExplicit},
+	}
+
+	for _, test := range tests {
+		s.SessionSettings.Set(config.ResendRequestEndSeqNoStyle, test.config)
+		session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+		s.Nil(err)
+
+		s.Equal(test.style, session.ResendRequestEndSeqNoStyle)
+	}
+}
+
+func (s *SessionFactorySuite) TestNewSessionResendRequestTimeout() {
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(time.Duration(0), session.ResendRequestTimeout)
+	s.Equal(3, session.MaxResendRequestRetries)
+	s.Equal(0, session.MaxMessageStashSize)
+
+	s.SessionSettings.Set(config.ResendRequestTimeout, "10")
+	s.SessionSettings.Set(config.MaxResendRequestRetries, "0")
+	s.SessionSettings.Set(config.MaxMessageStashSize, "1000")
+	session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.Equal(10*time.Second, session.ResendRequestTimeout)
+	s.Equal(0, session.MaxResendRequestRetries)
+	s.Equal(1000, session.MaxMessageStashSize)
+
+	s.SessionSettings.Set(config.ResendRequestTimeout, "-1")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "ResendRequestTimeout must not be negative")
+
+	s.SessionSettings.Set(config.ResendRequestTimeout, "10")
+	s.SessionSettings.Set(config.MaxResendRequestRetries, "-1")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "MaxResendRequestRetries must not be negative")
+
+	s.SessionSettings.Set(config.MaxResendRequestRetries, "3")
+	s.SessionSettings.Set(config.MaxMessageStashSize, "0")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err, "MaxMessageStashSize must be greater than zero")
+}
+
+func (s *SessionFactorySuite) TestNewSessionComplianceLevel() {
+	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.Nil(err)
+	s.False(session.StrictCompliance)
+	s.False(session.LenientCompliance)
+
+	s.SessionSettings.Set(config.SessionComplianceLevel, "blah")
+	_, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+	s.NotNil(err)
+
+	var tests = []struct {
+		config  string
+		strict  bool
+		lenient bool
+	}{
+		{"standard", false, false},
+		{"strict", true, false},
+		{"lenient", false, true},
+	}
+
+	for _, test := range tests {
+		s.SessionSettings.Set(config.SessionComplianceLevel, test.config)
+		session, err = s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
+		s.Nil(err)
+
+		s.Equal(test.strict, session.StrictCompliance)
+		s.Equal(test.lenient, session.LenientCompliance)
+	}
+}
+
 func (s *SessionFactorySuite) TestNewSessionMaxLatency() {
 	s.SessionSettings.Set(config.MaxLatency, "not a number")
 	session, err := s.newSession(s.SessionID, s.MessageStoreFactory, s.SessionSettings, s.LogFactory, s.App)
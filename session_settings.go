@@ -84,6 +84,21 @@ func (s *SessionSettings) IntSetting(setting string) (val int, err error) {
 	return
 }
 
+//FloatSetting returns the requested setting parsed as a float64. Returns an error if the setting is not set or cannot be parsed as a float64.
+func (s *SessionSettings) FloatSetting(setting string) (val float64, err error) {
+	stringVal, err := s.Setting(setting)
+
+	if err != nil {
+		return
+	}
+
+	if val, err = strconv.ParseFloat(stringVal, 64); err != nil {
+		return val, IncorrectFormatForSetting{Setting: setting, Value: stringVal, Err: err}
+	}
+
+	return
+}
+
 //DurationSetting returns the requested setting parsed as a time.Duration.
 //Returns an error if the setting is not set or cannot be parsed as a time.Duration.
 func (s *SessionSettings) DurationSetting(setting string) (val time.Duration, err error) {
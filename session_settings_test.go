@@ -55,6 +55,28 @@ func TestSessionSettings_IntSettings(t *testing.T) {
 	}
 }
 
+func TestSessionSettings_FloatSettings(t *testing.T) {
+	s := NewSessionSettings()
+	if _, err := s.FloatSetting(config.TestRequestDelayMultiplier); err == nil {
+		t.Error("Expected error for unknown setting")
+	}
+
+	s.Set(config.TestRequestDelayMultiplier, "notafloat")
+	if _, err := s.FloatSetting(config.TestRequestDelayMultiplier); err == nil {
+		t.Error("Expected error for unparsable value")
+	}
+
+	s.Set(config.TestRequestDelayMultiplier, "1.5")
+	val, err := s.FloatSetting(config.TestRequestDelayMultiplier)
+	if err != nil {
+		t.Error("Unexpected err", err)
+	}
+
+	if val != 1.5 {
+		t.Errorf("Expected %v, got %v", 1.5, val)
+	}
+}
+
 func TestSessionSettings_BoolSettings(t *testing.T) {
 	s := NewSessionSettings()
 	if _, err := s.BoolSetting(config.ResetOnLogon); err == nil {
@@ -19,7 +19,7 @@ func (sm *stateMachine) Start(s *session) {
 	sm.stopped = false
 
 	sm.State = latentState{}
-	sm.CheckSessionTime(s, time.Now())
+	sm.CheckSessionTime(s, s.clock.Now())
 }
 
 func (sm *stateMachine) Connect(session *session) {
@@ -49,7 +49,7 @@ func (sm *stateMachine) Connect(session *session) {
 
 	sm.setState(session, logonState{})
 	// Fire logon timeout event after the pre-configured delay period.
-	time.AfterFunc(session.LogonTimeout, func() { session.sessionEvent <- internal.LogonTimeout })
+	session.clock.AfterFunc(session.LogonTimeout, func() { session.sessionEvent <- internal.LogonTimeout })
 }
 
 func (sm *stateMachine) Stop(session *session) {
@@ -68,7 +68,7 @@ func (sm *stateMachine) Disconnected(session *session) {
 }
 
 func (sm *stateMachine) Incoming(session *session, m fixIn) {
-	sm.CheckSessionTime(session, time.Now())
+	sm.CheckSessionTime(session, session.clock.Now())
 	if !sm.IsConnected() {
 		return
 	}
@@ -83,7 +83,7 @@ func (sm *stateMachine) Incoming(session *session, m fixIn) {
 
 	session.log.OnIncoming(sm.logMsgBuffer[:msgLen])
 
-	msg := session.messagePool.Get()
+	msg := sharedMessagePool.Get()
 	if err := ParseMessageWithDataDictionary(msg, m.bytes, session.transportDataDictionary, session.appDataDictionary); err != nil {
 		session.log.OnEventf("Msg Parse Error: %v, %q", err.Error(), m.bytes)
 	} else {
@@ -91,10 +91,13 @@ func (sm *stateMachine) Incoming(session *session, m fixIn) {
 		sm.fixMsgIn(session, msg)
 	}
 
-	if !msg.keepMessage {
-		session.returnToPool(msg)
+	//messages retained by the application or stashed while resending outlive this call
+	msg.Release()
+
+	//while a test request is outstanding the peer timer measures the heartbeat timeout from the request
+	if _, ok := sm.State.(pendingTimeout); !ok {
+		session.resetPeerTimer(session.testRequestDelay())
 	}
-	session.peerTimer.Reset(time.Duration(float64(1.2) * float64(session.HeartBtInt)))
 }
 
 func (sm *stateMachine) fixMsgIn(session *session, m *Message) {
@@ -102,7 +105,7 @@ func (sm *stateMachine) fixMsgIn(session *session, m *Message) {
 }
 
 func (sm *stateMachine) SendAppMessages(session *session) {
-	sm.CheckSessionTime(session, time.Now())
+	sm.CheckSessionTime(session, session.clock.Now())
 
 	session.sendMutex.Lock()
 	defer session.sendMutex.Unlock()
@@ -115,14 +118,21 @@ func (sm *stateMachine) SendAppMessages(session *session) {
 }
 
 func (sm *stateMachine) Timeout(session *session, e internal.Event) {
-	sm.CheckSessionTime(session, time.Now())
+	sm.CheckSessionTime(session, session.clock.Now())
 	sm.setState(session, sm.State.Timeout(session, e))
 }
 
+//CheckSessionTime holds the session in notSessionTime outside of the session window or the logon window. The store is
+//reset only when the session window changes, so a session may log out and back on without a reset.
 func (sm *stateMachine) CheckSessionTime(session *session, now time.Time) {
-	if !session.SessionTime.IsInRange(now) {
+	inSession := session.SessionTime.IsInRange(now)
+	if !inSession || !session.LogonTime.IsInRange(now) {
 		if sm.IsSessionTime() {
-			session.log.OnEvent("Not in session")
+			if inSession {
+				session.log.OnEvent("Not in logon time")
+			} else {
+				session.log.OnEvent("Not in session")
+			}
 		}
 
 		sm.State.ShutdownNow(session)
@@ -2,9 +2,11 @@ package quickfix
 
 import (
 	"bytes"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/quickfixgo/quickfix/clocktest"
 	"github.com/quickfixgo/quickfix/internal"
 
 	"github.com/stretchr/testify/require"
@@ -175,6 +177,25 @@ func (s *SessionSuite) TestCheckTargetTooHigh() {
 	s.Nil(s.session.checkTargetTooHigh(msg))
 }
 
+func (s *SessionSuite) TestSendingTimeVirtualTime() {
+	now := time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC)
+	clock := clocktest.NewFakeClock(now)
+	s.session.clock = clock
+	s.session.MaxLatency = 120 * time.Second
+
+	msg := NewMessage()
+	s.session.insertSendingTime(msg)
+	sendingTime, err := msg.Header.GetTime(tagSendingTime)
+	s.Require().Nil(err)
+	s.True(now.Equal(sendingTime))
+	s.Nil(s.session.checkSendingTime(msg))
+
+	clock.Advance(121 * time.Second)
+	err = s.session.checkSendingTime(msg)
+	s.Require().NotNil(err)
+	s.Equal(rejectReasonSendingTimeAccuracyProblem, err.RejectReason())
+}
+
 func (s *SessionSuite) TestCheckSendingTime() {
 	s.session.MaxLatency = time.Duration(120) * time.Second
 	msg := NewMessage()
@@ -362,6 +383,40 @@ func (s *SessionSuite) TestCheckSessionTimeInRange() {
 	}
 }
 
+func (s *SessionSuite) TestCheckSessionTimeFakeClock() {
+	start := time.Date(2024, time.December, 16, 9, 0, 0, 0, time.UTC)
+	clock := clocktest.NewFakeClock(start)
+
+	store, err := NewMemoryStoreFactory().Create(s.sessionID)
+	s.Require().Nil(err)
+	s.session.store = store
+	s.session.setClock(clock)
+	s.session.State = latentState{}
+	s.session.SessionTime = internal.NewUTCTimeRange(internal.NewTimeOfDay(8, 0, 0), internal.NewTimeOfDay(17, 0, 0))
+
+	//the store created from the wall clock is reset once into the window of the clock
+	s.session.CheckSessionTime(s.session, clock.Now())
+	s.Equal(start, store.CreationTime())
+
+	s.IncrNextSenderMsgSeqNum()
+	clock.Advance(time.Hour)
+	s.session.CheckSessionTime(s.session, clock.Now())
+	s.NextSenderMsgSeqNum(2)
+	s.Equal(start, store.CreationTime())
+
+	//a later session window resets the store once
+	clock.Set(start.Add(24 * time.Hour))
+	s.session.CheckSessionTime(s.session, clock.Now())
+	s.State(latentState{})
+	s.ExpectStoreReset()
+	s.Equal(clock.Now(), store.CreationTime())
+
+	s.IncrNextSenderMsgSeqNum()
+	clock.Advance(time.Minute)
+	s.session.CheckSessionTime(s.session, clock.Now())
+	s.NextSenderMsgSeqNum(2)
+}
+
 func (s *SessionSuite) TestCheckSessionTimeNotInRange() {
 	var tests = []struct {
 		before           sessionState
@@ -415,6 +470,36 @@ func (s *SessionSuite) TestCheckSessionTimeNotInRange() {
 	}
 }
 
+func (s *SessionSuite) TestCheckSessionTimeNotInLogonTime() {
+	s.session.State = inSession{}
+	s.IncrNextSenderMsgSeqNum()
+	s.IncrNextTargetMsgSeqNum()
+
+	now := time.Now().UTC()
+	s.session.SessionTime = internal.NewUTCTimeRange(
+		internal.NewTimeOfDay(now.Add(time.Duration(-1)*time.Hour).Clock()),
+		internal.NewTimeOfDay(now.Add(time.Duration(3)*time.Hour).Clock()),
+	)
+	s.session.LogonTime = internal.NewUTCTimeRange(
+		internal.NewTimeOfDay(now.Add(time.Hour).Clock()),
+		internal.NewTimeOfDay(now.Add(time.Duration(2)*time.Hour).Clock()),
+	)
+
+	s.MockApp.On("OnLogout")
+	s.MockApp.On("ToAdmin")
+	s.session.CheckSessionTime(s.session, now)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(notSessionTime{})
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeLogout), s.MockApp.lastToAdmin)
+
+	s.session.CheckSessionTime(s.session, now.Add(90*time.Minute))
+	s.State(latentState{})
+	s.NextTargetMsgSeqNum(2)
+	s.NextSenderMsgSeqNum(3)
+}
+
 func (s *SessionSuite) TestCheckSessionTimeInRangeButNotSameRangeAsStore() {
 	var tests = []struct {
 		before           sessionState
@@ -626,6 +711,70 @@ func (s *SessionSuite) TestOnAdminConnectInitiateLogon() {
 	s.NextSenderMsgSeqNum(3)
 }
 
+func (s *SessionSuite) TestOnAdminConnectInitiateLogonTimeoutVirtualTime() {
+	clock := clocktest.NewFakeClock(time.Now())
+	s.session.clock = clock
+	s.session.State = latentState{}
+	s.session.InitiateLogon = true
+	s.session.LogonTimeout = 10 * time.Second
+
+	s.MockApp.On("ToAdmin")
+	s.session.onAdmin(connect{messageOut: s.Receiver.sendChannel})
+	s.State(logonState{})
+	s.Equal(1, clock.PendingTimers())
+
+	events := make(chan internal.Event, 1)
+	go func() { events <- <-s.session.sessionEvent }()
+
+	clock.Advance(9 * time.Second)
+	select {
+	case <-events:
+		s.Fail("logon timeout fired early")
+	default:
+	}
+
+	clock.Advance(time.Second)
+	s.Equal(internal.LogonTimeout, <-events)
+}
+
+func (s *SessionSuite) TestOnAdminConnectInitiateLogonCredentials() {
+	adminMsg := connect{
+		messageOut: s.Receiver.sendChannel,
+	}
+	s.session.State = latentState{}
+	s.session.HeartBtInt = time.Duration(45) * time.Second
+	s.session.InitiateLogon = true
+	s.session.credentials = NewStaticCredentialsProvider(Credentials{Username: "user", Password: "pass", NewPassword: "next"})
+
+	s.MockApp.On("ToAdmin")
+	s.session.onAdmin(adminMsg)
+
+	s.MockApp.AssertExpectations(s.T())
+	s.State(logonState{})
+	s.LastToAdminMessageSent()
+	s.MessageType(string(msgTypeLogon), s.MockApp.lastToAdmin)
+	s.FieldEquals(tagUsername, "user", s.MockApp.lastToAdmin.Body)
+	s.FieldEquals(tagPassword, "pass", s.MockApp.lastToAdmin.Body)
+	s.FieldEquals(tagNewPassword, "next", s.MockApp.lastToAdmin.Body)
+}
+
+func (s *SessionSuite) TestOnAdminConnectInitiateLogonCredentialsError() {
+	adminMsg := connect{
+		messageOut: s.Receiver.sendChannel,
+	}
+	s.session.State = latentState{}
+	s.session.InitiateLogon = true
+	s.session.credentials = CredentialsProviderFunc(func(SessionID) (Credentials, error) {
+		return Credentials{}, errors.New("vault unavailable")
+	})
+
+	s.session.onAdmin(adminMsg)
+
+	s.MockApp.AssertNotCalled(s.T(), "ToAdmin")
+	s.NoMessageSent()
+	s.NextSenderMsgSeqNum(1)
+}
+
 func (s *SessionSuite) TestInitiateLogonResetSeqNumFlag() {
 	adminMsg := connect{
 		messageOut: s.Receiver.sendChannel,
@@ -14,12 +14,16 @@ import (
 type Settings struct {
 	globalSettings  *SessionSettings
 	sessionSettings map[SessionID]*SessionSettings
+
+	//acceptor templates, in the order they were added
+	templates []*acceptorTemplate
 }
 
 //Init initializes or resets a Settings instance
 func (s *Settings) Init() {
 	s.globalSettings = NewSessionSettings()
 	s.sessionSettings = make(map[SessionID]*SessionSettings)
+	s.templates = nil
 }
 
 func (s *Settings) lazyInit() {
@@ -149,7 +153,8 @@ func (s *Settings) SessionSettings() map[SessionID]*SessionSettings {
 	return allSessionSettings
 }
 
-//AddSession adds Session Settings to Settings instance. Returns an error if session settings with duplicate sessionID has already been added
+//AddSession adds Session Settings to Settings instance. Returns an error if session settings with duplicate sessionID has already been added.
+//Session settings with AcceptorTemplate=Y are added as acceptor templates rather than sessions.
 func (s *Settings) AddSession(sessionSettings *SessionSettings) (SessionID, error) {
 	s.lazyInit()
 
@@ -166,6 +171,15 @@ func (s *Settings) AddSession(sessionSettings *SessionSettings) (SessionID, erro
 		return sessionID, errors.New("BeginString must be FIX.4.0 to FIX.4.4 or FIXT.1.1")
 	}
 
+	isTemplate, err := isAcceptorTemplate(sessionSettings)
+	if err != nil {
+		return sessionID, err
+	}
+
+	if isTemplate {
+		return sessionID, s.addAcceptorTemplate(sessionID, sessionSettings)
+	}
+
 	if _, dup := s.sessionSettings[sessionID]; dup {
 		return sessionID, fmt.Errorf("duplicate session configured for %v", sessionID)
 	}
@@ -174,3 +188,62 @@ func (s *Settings) AddSession(sessionSettings *SessionSettings) (SessionID, erro
 
 	return sessionID, nil
 }
+
+func (s *Settings) addAcceptorTemplate(templateID SessionID, sessionSettings *SessionSettings) error {
+	for _, t := range s.templates {
+		if t.templateID == templateID {
+			return fmt.Errorf("duplicate acceptor template configured for %v", templateID)
+		}
+	}
+
+	template, err := newAcceptorTemplate(templateID, sessionSettings)
+	if err != nil {
+		return err
+	}
+
+	s.templates = append(s.templates, template)
+	return nil
+}
+
+//AcceptorTemplateSettings returns the settings of all acceptor templates overlaying globalsettings, keyed by template session ID.
+func (s *Settings) AcceptorTemplateSettings() map[SessionID]*SessionSettings {
+	allTemplateSettings := make(map[SessionID]*SessionSettings)
+
+	for _, t := range s.templates {
+		cloneSettings := s.globalSettings.clone()
+		cloneSettings.overlay(t.settings)
+		allTemplateSettings[t.templateID] = cloneSettings
+	}
+
+	return allTemplateSettings
+}
+
+//acceptorTemplateFor returns the first acceptor template that matches sessionID.
+func (s *Settings) acceptorTemplateFor(sessionID SessionID) (*acceptorTemplate, bool) {
+	for _, t := range s.templates {
+		if t.matches(sessionID) {
+			return t, true
+		}
+	}
+
+	return nil, false
+}
+
+//sessionSettingsFor returns the settings for sessionID overlaying globalsettings. Sessions not explicitly configured
+//use the settings of the first matching acceptor template.
+func (s *Settings) sessionSettingsFor(sessionID SessionID) (*SessionSettings, bool) {
+	s.lazyInit()
+
+	settings, ok := s.sessionSettings[sessionID]
+	if !ok {
+		var t *acceptorTemplate
+		if t, ok = s.acceptorTemplateFor(sessionID); !ok {
+			return nil, false
+		}
+		settings = t.settings
+	}
+
+	cloneSettings := s.globalSettings.clone()
+	cloneSettings.overlay(settings)
+	return cloneSettings, true
+}
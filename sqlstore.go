@@ -35,7 +35,7 @@ func NewSQLStoreFactory(settings *Settings, db *sql.DB, targetSeqNumDebounceInte
 
 // Create creates a new SQLStore implementation of the MessageStore interface
 func (f sqlStoreFactory) Create(sessionID SessionID) (msgStore MessageStore, err error) {
-	sessionSettings, ok := f.settings.SessionSettings()[sessionID]
+	sessionSettings, ok := f.settings.sessionSettingsFor(sessionID)
 	if !ok {
 		return nil, fmt.Errorf("unknown session: %v", sessionID)
 	}
@@ -286,6 +286,10 @@ func (store *sqlStore) CreationTime() time.Time {
 	return store.cache.CreationTime()
 }
 
+func (store *sqlStore) setClock(clock Clock) {
+	store.cache.setClock(clock)
+}
+
 func (store *sqlStore) SaveMessage(seqNum int, msg []byte) error {
 	s := store.sessionID
 
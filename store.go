@@ -15,6 +15,7 @@ type MessageStore interface {
 
 	CreationTime() time.Time
 
+	//SaveMessage records a sent message, msg is reused once written to the connection and must be copied if kept
 	SaveMessage(seqNum int, msg []byte) error
 	GetMessages(beginSeqNum, endSeqNum int) ([][]byte, error)
 
@@ -24,6 +25,12 @@ type MessageStore interface {
 	Close() error
 }
 
+//clockedStore is implemented by the stores of this package, which stamp their creation time with the session Clock so
+//that the session schedule can be run in virtual time
+type clockedStore interface {
+	setClock(clock Clock)
+}
+
 //The MessageStoreFactory interface is used by session to create a session specific message store
 type MessageStoreFactory interface {
 	Create(sessionID SessionID) (MessageStore, error)
@@ -33,6 +40,19 @@ type memoryStore struct {
 	senderMsgSeqNum, targetMsgSeqNum int
 	creationTime                     time.Time
 	messageMap                       map[int][]byte
+	clock                            Clock
+}
+
+func (store *memoryStore) setClock(clock Clock) {
+	store.clock = clock
+}
+
+func (store *memoryStore) now() time.Time {
+	if store.clock == nil {
+		return time.Now()
+	}
+
+	return store.clock.Now()
 }
 
 func (store *memoryStore) NextSenderMsgSeqNum() int {
@@ -69,7 +89,7 @@ func (store *memoryStore) CreationTime() time.Time {
 func (store *memoryStore) Reset() error {
 	store.senderMsgSeqNum = 0
 	store.targetMsgSeqNum = 0
-	store.creationTime = time.Now()
+	store.creationTime = store.now()
 	store.messageMap = nil
 	return nil
 }
@@ -89,7 +109,8 @@ func (store *memoryStore) SaveMessage(seqNum int, msg []byte) error {
 		store.messageMap = make(map[int][]byte)
 	}
 
-	store.messageMap[seqNum] = msg
+	//msg is reused once sent, keep a copy
+	store.messageMap[seqNum] = append([]byte(nil), msg...)
 	return nil
 }
 
@@ -82,6 +82,21 @@ func (suite *MessageStoreTestSuite) TestMessageStore_Reset() {
 	assert.Equal(t, 1, suite.msgStore.NextTargetMsgSeqNum())
 }
 
+func (suite *MessageStoreTestSuite) TestMessageStore_SaveMessage_ReusedBuffer() {
+	t := suite.T()
+
+	// Given a message saved from a buffer that is reused once sent
+	buffer := []byte("hello")
+	require.Nil(t, suite.msgStore.SaveMessage(1, buffer))
+	copy(buffer, "world")
+
+	// Then the saved message is unchanged
+	actualMsgs, err := suite.msgStore.GetMessages(1, 1)
+	require.Nil(t, err)
+	require.Len(t, actualMsgs, 1)
+	assert.Equal(t, "hello", string(actualMsgs[0]))
+}
+
 func (suite *MessageStoreTestSuite) TestMessageStore_SaveMessage_GetMessage() {
 	t := suite.T()
 
@@ -39,21 +39,26 @@ const (
 	tagHopSendingTime         Tag = 629
 	tagHopRefID               Tag = 630
 
-	tagHeartBtInt           Tag = 108
-	tagBusinessRejectReason Tag = 380
-	tagSessionRejectReason  Tag = 373
-	tagRefMsgType           Tag = 372
-	tagRefTagID             Tag = 371
-	tagRefSeqNum            Tag = 45
-	tagEncryptMethod        Tag = 98
-	tagResetSeqNumFlag      Tag = 141
-	tagDefaultApplVerID     Tag = 1137
-	tagText                 Tag = 58
-	tagTestReqID            Tag = 112
-	tagGapFillFlag          Tag = 123
-	tagNewSeqNo             Tag = 36
-	tagBeginSeqNo           Tag = 7
-	tagEndSeqNo             Tag = 16
+	tagHeartBtInt            Tag = 108
+	tagBusinessRejectReason  Tag = 380
+	tagSessionRejectReason   Tag = 373
+	tagRefMsgType            Tag = 372
+	tagRefTagID              Tag = 371
+	tagRefSeqNum             Tag = 45
+	tagEncryptMethod         Tag = 98
+	tagResetSeqNumFlag       Tag = 141
+	tagDefaultApplVerID      Tag = 1137
+	tagText                  Tag = 58
+	tagTestReqID             Tag = 112
+	tagGapFillFlag           Tag = 123
+	tagNewSeqNo              Tag = 36
+	tagBeginSeqNo            Tag = 7
+	tagEndSeqNo              Tag = 16
+	tagSessionStatus         Tag = 1409
+	tagUsername              Tag = 553
+	tagPassword              Tag = 554
+	tagNewPassword           Tag = 925
+	tagNextExpectedMsgSeqNum Tag = 789
 
 	tagSignatureLength Tag = 93
 	tagSignature       Tag = 89
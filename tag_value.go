@@ -3,7 +3,6 @@ package quickfix
 import (
 	"bytes"
 	"fmt"
-	"strconv"
 )
 
 //TagValue is a low-level FIX field abstraction
@@ -14,10 +13,9 @@ type TagValue struct {
 }
 
 func (tv *TagValue) init(tag Tag, value []byte) {
-	tv.bytes = strconv.AppendInt(nil, int64(tag), 10)
-	tv.bytes = append(tv.bytes, []byte("=")...)
+	tv.bytes = appendTagPrefix(make([]byte, 0, maxTagPrefixLen+len(value)+1), tag)
 	tv.bytes = append(tv.bytes, value...)
-	tv.bytes = append(tv.bytes, []byte("")...)
+	tv.bytes = append(tv.bytes, '\001')
 
 	tv.tag = tag
 	tv.value = value
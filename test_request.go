@@ -0,0 +1,124 @@
+package quickfix
+
+import (
+	"fmt"
+	"time"
+)
+
+//testRequestDelay returns the time without inbound messages after which a TestRequest is sent.
+func (s *session) testRequestDelay() time.Duration {
+	return time.Duration(s.TestRequestDelayMultiplier * float64(s.HeartBtInt))
+}
+
+//heartbeatTimeout returns the time to wait for a reply to a TestRequest.
+func (s *session) heartbeatTimeout() time.Duration {
+	return time.Duration(s.HeartbeatTimeoutMultiplier * float64(s.HeartBtInt))
+}
+
+//resetHeartbeatTimer schedules the next Heartbeat. Sessions with HeartBtInt=0 do not send heartbeats.
+func (s *session) resetHeartbeatTimer() {
+	if s.HeartBtInt > 0 {
+		s.stateTimer.Reset(s.HeartBtInt)
+	}
+}
+
+//resetPeerTimer schedules the next check for an unresponsive peer. Sessions with HeartBtInt=0 do not supervise the peer.
+func (s *session) resetPeerTimer(timeout time.Duration) {
+	if s.HeartBtInt > 0 {
+		s.peerTimer.Reset(timeout)
+	}
+}
+
+//checkLogonHeartBtInt rejects a logon request with a HeartBtInt outside the MinHeartBtInt and MaxHeartBtInt bounds of
+//the acceptor.
+func (s *session) checkLogonHeartBtInt(logon *Message) error {
+	if !logon.Body.Has(tagHeartBtInt) {
+		return nil
+	}
+
+	var heartBtIntSeconds FIXInt
+	if err := logon.Body.GetField(tagHeartBtInt, &heartBtIntSeconds); err != nil {
+		return err
+	}
+
+	heartBtInt := time.Duration(heartBtIntSeconds) * time.Second
+	switch {
+	case heartBtInt < 0:
+		return RejectLogon{fmt.Sprintf("HeartBtInt %v must not be negative", heartBtIntSeconds)}
+	case heartBtInt < s.MinHeartBtInt:
+		return RejectLogon{fmt.Sprintf("HeartBtInt %v is less than the minimum of %v", heartBtIntSeconds, s.MinHeartBtInt.Seconds())}
+	case s.MaxHeartBtInt > 0 && heartBtInt > s.MaxHeartBtInt:
+		return RejectLogon{fmt.Sprintf("HeartBtInt %v is greater than the maximum of %v", heartBtIntSeconds, s.MaxHeartBtInt.Seconds())}
+	}
+
+	return nil
+}
+
+//adoptLogonHeartBtInt uses the HeartBtInt of a verified logon request for the session.
+func (s *session) adoptLogonHeartBtInt(logon *Message) {
+	var heartBtInt FIXInt
+	if err := logon.Body.GetField(tagHeartBtInt, &heartBtInt); err != nil {
+		return
+	}
+
+	if heartBtInt == 0 {
+		s.log.OnEvent("Logon request with HeartBtInt=0, heartbeats disabled")
+	}
+
+	s.HeartBtInt = time.Duration(heartBtInt) * time.Second
+}
+
+//nextTestReqID returns a TestReqID unique to the session.
+func (s *session) nextTestReqID() string {
+	s.testReqCounter++
+	return fmt.Sprintf("TEST-%v-%v", s.clock.Now().UTC().Format("20060102-15:04:05.000"), s.testReqCounter)
+}
+
+//sendTestRequest sends a TestRequest with a unique TestReqID and waits for a reply.
+func (s *session) sendTestRequest() error {
+	testReqID := s.nextTestReqID()
+
+	testReq := NewMessage()
+	testReq.Header.SetField(tagMsgType, FIXString(msgTypeTestRequest))
+	testReq.Body.SetField(tagTestReqID, FIXString(testReqID))
+	if err := s.send(testReq); err != nil {
+		return err
+	}
+
+	s.pendingTestReqIDs = append(s.pendingTestReqIDs, testReqID)
+	s.log.OnEventf("Sent test request %v", testReqID)
+	s.resetPeerTimer(s.heartbeatTimeout())
+	return nil
+}
+
+//isTestRequestReply returns true if msg is a Heartbeat with the TestReqID of an outstanding test request.
+func (s *session) isTestRequestReply(msg *Message) bool {
+	var testReqID FIXString
+	if err := msg.Body.GetField(tagTestReqID, &testReqID); err != nil {
+		return false
+	}
+
+	for _, pending := range s.pendingTestReqIDs {
+		if pending == string(testReqID) {
+			return true
+		}
+	}
+
+	return false
+}
+
+//checkHeartbeatTestReqID clears the outstanding test requests once a Heartbeat replies to one of them.
+func (s *session) checkHeartbeatTestReqID(heartbeat *Message) {
+	if !heartbeat.Body.Has(tagTestReqID) {
+		return
+	}
+
+	testReqID, _ := heartbeat.Body.GetString(tagTestReqID)
+	if s.isTestRequestReply(heartbeat) {
+		s.pendingTestReqIDs = nil
+		s.log.OnEventf("Received heartbeat for test request %v", testReqID)
+		return
+	}
+
+	s.log.OnEventf("Received heartbeat with unknown TestReqID %v", testReqID)
+}
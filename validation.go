@@ -135,11 +135,14 @@ func validateWalk(transportDD *datadictionary.DataDictionary, appDD *datadiction
 		field := remainingFields[0]
 		tag := field.tag
 
+		fieldMap := msg.Body.FieldMap
 		switch {
 		case tag.IsHeader():
 			messageDef = transportDD.Header
+			fieldMap = msg.Header.FieldMap
 		case tag.IsTrailer():
 			messageDef = transportDD.Trailer
+			fieldMap = msg.Trailer.FieldMap
 		default: // is body
 			messageDef = appDD.Messages[msgType]
 		}
@@ -153,6 +156,15 @@ func validateWalk(transportDD *datadictionary.DataDictionary, appDD *datadiction
 		}
 		iteratedTags.Add(int(tag))
 
+		//groups built while parsing are checked instance by instance before the count and order of their fields
+		if fieldDef.IsGroup() {
+			if rg, ok := parsedRepeatingGroup(fieldMap, remainingFields); ok {
+				if err = validateVisitRepeatingGroup(fieldDef, rg); err != nil {
+					return err
+				}
+			}
+		}
+
 		if remainingFields, err = validateVisitField(fieldDef, remainingFields); err != nil {
 			return err
 		}
@@ -224,6 +236,65 @@ func validateVisitGroupField(fieldDef *datadictionary.FieldDef, fieldStack []Tag
 	return fieldStack, nil
 }
 
+//parsedRepeatingGroup returns the repeating group built while parsing that starts at the first of fields
+func parsedRepeatingGroup(fieldMap FieldMap, fields []TagValue) (*RepeatingGroup, bool) {
+	rg, ok := fieldMap.groups[fields[0].tag]
+	if !ok {
+		return nil, false
+	}
+
+	f := fieldMap.tagLookup[fields[0].tag]
+	if len(f) == 0 || len(f) > len(fields) || &f[0] != &fields[0] {
+		return nil, false
+	}
+
+	return rg, true
+}
+
+func validateVisitRepeatingGroup(fieldDef *datadictionary.FieldDef, rg *RepeatingGroup) MessageRejectError {
+	for _, group := range rg.groups {
+		if err := validateVisitGroup(fieldDef, group); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//validateVisitGroup checks the fields of group occur in the order defined by fieldDef, and that required fields are
+//present
+func validateVisitGroup(fieldDef *datadictionary.FieldDef, group *Group) MessageRejectError {
+	childDefs := fieldDef.Fields
+	for _, tag := range group.tags {
+		for len(childDefs) > 0 && Tag(childDefs[0].Tag()) != tag {
+			if childDefs[0].Required() {
+				return RequiredTagMissing(Tag(childDefs[0].Tag()))
+			}
+			childDefs = childDefs[1:]
+		}
+
+		if len(childDefs) == 0 {
+			return tagSpecifiedOutOfRequiredOrder(tag)
+		}
+
+		if rg, ok := group.groups[tag]; ok {
+			if err := validateVisitRepeatingGroup(childDefs[0], rg); err != nil {
+				return err
+			}
+		}
+
+		childDefs = childDefs[1:]
+	}
+
+	for _, childDef := range childDefs {
+		if childDef.Required() {
+			return RequiredTagMissing(Tag(childDef.Tag()))
+		}
+	}
+
+	return nil
+}
+
 func validateOrder(msg *Message) MessageRejectError {
 	inHeader := true
 	inTrailer := false
@@ -315,16 +386,40 @@ func validateField(d *datadictionary.DataDictionary, validFields datadictionary.
 	case "MULTIPLESTRINGVALUE", "MULTIPLEVALUESTRING":
 		fallthrough
 	case "MULTIPLECHARVALUE":
-		fallthrough
+		prototype = new(FIXMultipleValueString)
+
 	case "CHAR":
-		fallthrough
-	case "CURRENCY":
-		fallthrough
+		//FIX 4.0 and 4.1 use char for string values
+		if d.FIXType == "FIX" && d.Major == 4 && d.Minor < 2 {
+			prototype = new(FIXString)
+		} else {
+			prototype = new(FIXChar)
+		}
+
 	case "DATA":
-		fallthrough
+		prototype = new(FIXData)
+
 	case "MONTHYEAR":
+		prototype = new(FIXMonthYear)
+
+	case "LOCALMKTDATE":
+		prototype = new(FIXLocalMktDate)
+
+	case "UTCTIMEONLY":
+		prototype = new(FIXUTCTimeOnly)
+
+	case "UTCDATEONLY", "UTCDATE":
+		prototype = new(FIXUTCDateOnly)
+
+	case "TZTIMEONLY":
+		prototype = new(FIXTZTimeOnly)
+
+	case "TZTIMESTAMP":
+		prototype = new(FIXTZTimestamp)
+
+	case "CURRENCY":
 		fallthrough
-	case "LOCALMKTDATE", "DATE":
+	case "DATE":
 		fallthrough
 	case "EXCHANGE":
 		fallthrough
@@ -334,14 +429,6 @@ func validateField(d *datadictionary.DataDictionary, validFields datadictionary.
 		fallthrough
 	case "COUNTRY":
 		fallthrough
-	case "UTCTIMEONLY":
-		fallthrough
-	case "UTCDATEONLY", "UTCDATE":
-		fallthrough
-	case "TZTIMEONLY":
-		fallthrough
-	case "TZTIMESTAMP":
-		fallthrough
 	case "STRING":
 		prototype = new(FIXString)
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/quickfixgo/quickfix/datadictionary"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 type validateTest struct {
@@ -27,6 +28,7 @@ func TestValidate(t *testing.T) {
 		tcInvalidMsgType(),
 		tcValueIsIncorrect(),
 		tcIncorrectDataFormatForValue(),
+		tcIncorrectDataFormatForMonthYear(),
 		tcTagSpecifiedOutOfRequiredOrderHeader(),
 		tcTagSpecifiedOutOfRequiredOrderTrailer(),
 		tcTagSpecifiedOutOfRequiredOrderDisabledHeader(),
@@ -341,6 +343,23 @@ func tcIncorrectDataFormatForValue() validateTest {
 	}
 }
 
+func tcIncorrectDataFormatForMonthYear() validateTest {
+	dict, _ := datadictionary.Parse("spec/FIX43.xml")
+	validator := &fixValidator{dict, defaultValidatorSettings}
+	builder := createFIX43NewOrderSingle()
+	tag := Tag(200)
+	builder.Body.SetField(tag, FIXString("200913"))
+	msgBytes := builder.build()
+
+	return validateTest{
+		TestName:             "IncorrectDataFormatForMonthYear",
+		Validator:            validator,
+		MessageBytes:         msgBytes,
+		ExpectedRejectReason: rejectReasonIncorrectDataFormatForValue,
+		ExpectedRefTagID:     &tag,
+	}
+}
+
 func tcTagSpecifiedOutOfRequiredOrderHeader() validateTest {
 	dict, _ := datadictionary.Parse("spec/FIX40.xml")
 	validator := &fixValidator{dict, defaultValidatorSettings}
@@ -581,3 +600,49 @@ func TestValidateVisitField(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateParsedRepeatingGroups(t *testing.T) {
+	dict, err := datadictionary.Parse("spec/FIX44.xml")
+	require.Nil(t, err)
+	validator := &fixValidator{dict, defaultValidatorSettings}
+	tag := Tag(453)
+
+	var tests = []struct {
+		name                 string
+		rawMsg               string
+		expectedRejectReason int
+		expectReject         bool
+	}{
+		{
+			name:   "Valid group",
+			rawMsg: "8=FIX.4.4|9=0|35=D|34=2|49=TW|52=20060102-15:04:05|56=ISLD|11=ID|21=1|40=1|54=1|55=INTC|60=20060102-15:04:05|453=2|448=P1|447=D|452=1|448=P2|447=D|452=3|10=000|",
+		},
+		{
+			name:                 "Field before first delimiter",
+			rawMsg:               "8=FIX.4.4|9=0|35=D|34=2|49=TW|52=20060102-15:04:05|56=ISLD|11=ID|21=1|40=1|54=1|55=INTC|60=20060102-15:04:05|453=2|447=D|448=P1|452=1|448=P2|447=D|452=3|10=000|",
+			expectedRejectReason: rejectReasonIncorrectNumInGroupCountForRepeatingGroup,
+			expectReject:         true,
+		},
+		{
+			name:                 "Too few groups",
+			rawMsg:               "8=FIX.4.4|9=0|35=D|34=2|49=TW|52=20060102-15:04:05|56=ISLD|11=ID|21=1|40=1|54=1|55=INTC|60=20060102-15:04:05|453=3|448=P1|447=D|452=1|448=P2|447=D|452=3|10=000|",
+			expectedRejectReason: rejectReasonIncorrectNumInGroupCountForRepeatingGroup,
+			expectReject:         true,
+		},
+	}
+
+	for _, test := range tests {
+		msg := NewMessage()
+		require.Nil(t, ParseMessageWithDataDictionary(msg, newBuffer(test.rawMsg), dict, dict), test.name)
+
+		reject := validator.Validate(msg)
+		if !test.expectReject {
+			assert.Nil(t, reject, test.name)
+			continue
+		}
+
+		require.NotNil(t, reject, test.name)
+		assert.Equal(t, test.expectedRejectReason, reject.RejectReason(), test.name)
+		assert.Equal(t, &tag, reject.RefTagID(), test.name)
+	}
+}